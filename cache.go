@@ -0,0 +1,176 @@
+package marvel
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache stores response bodies so that repeated requests can be served
+// without calling the API.
+//
+// Keys are request URLs without authentication parameters. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the body stored for key, if any.
+	Get(key string) ([]byte, bool)
+	// Set stores the body of a successful response for key.
+	Set(key string, body []byte)
+}
+
+// CacheMode controls how a single request uses the Client's Cache.
+type CacheMode int
+
+const (
+	// CacheDefault serves the request from the cache if possible, and
+	// stores the response otherwise.
+	CacheDefault CacheMode = iota
+	// CacheBypass neither reads from nor writes to the cache.
+	CacheBypass
+	// CacheRefresh always calls the API, and stores the response.
+	CacheRefresh
+)
+
+type cacheModeKey struct{}
+
+// WithCacheMode returns a context that makes requests use the cache according
+// to mode.
+func WithCacheMode(ctx context.Context, mode CacheMode) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, mode)
+}
+
+func cacheMode(ctx context.Context) CacheMode {
+	mode, _ := ctx.Value(cacheModeKey{}).(CacheMode)
+	return mode
+}
+
+// LRUCache is a Cache that keeps a bounded number of responses in memory,
+// evicting the least recently used.
+type LRUCache struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+// NewLRUCache returns an LRUCache that holds up to size responses. If ttl is
+// positive, responses expire after that long.
+func NewLRUCache(size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return e.body, true
+}
+
+// Set implements Cache.
+func (c *LRUCache) Set(key string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = time.Now().Add(c.ttl)
+	}
+	if el, ok := c.items[key]; ok {
+		el.Value = &lruEntry{key, body, expires}
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&lruEntry{key, body, expires})
+	for c.ll.Len() > c.size {
+		el := c.ll.Back()
+		c.ll.Remove(el)
+		delete(c.items, el.Value.(*lruEntry).key)
+	}
+}
+
+// DiskCache is a Cache that stores responses as files in a directory, named
+// by the SHA-256 hash of their key.
+//
+// Errors reading or writing files are treated as cache misses.
+type DiskCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewDiskCache returns a DiskCache that stores responses in dir, creating it
+// if necessary. If ttl is positive, responses expire after that long.
+func NewDiskCache(dir string, ttl time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir, ttl: ttl}, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements Cache.
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	p := c.path(key)
+	if c.ttl > 0 {
+		fi, err := os.Stat(p)
+		if err != nil || time.Since(fi.ModTime()) > c.ttl {
+			return nil, false
+		}
+	}
+	body, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// Set implements Cache.
+func (c *DiskCache) Set(key string, body []byte) {
+	tmp, err := ioutil.TempFile(c.dir, ".tmp")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,84 @@
+package marvel
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2, 0)
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	c.Get("a")
+	c.Set("c", []byte("3"))
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("least recently used entry was not evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("Get(%q) missed", k)
+		}
+	}
+
+	c = NewLRUCache(2, time.Millisecond)
+	c.Set("a", []byte("1"))
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("expired entry was returned")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("NewDiskCache: %v", err)
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get on empty cache hit")
+	}
+	c.Set("a", []byte("1"))
+
+	// A new DiskCache in the same directory sees the entry.
+	c, err = NewDiskCache(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("NewDiskCache: %v", err)
+	}
+	if got, ok := c.Get("a"); !ok || string(got) != "1" {
+		t.Errorf("Get(a) = %q, %t; want 1, true", got, ok)
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var calls int
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"code":200,"data":{"results":[{"id":1009351}]}}`))
+	}))
+	c.Cache = NewLRUCache(10, 0)
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		ctx       context.Context
+		wantCalls int
+	}{
+		{ctx, 1},
+		{ctx, 1},
+		{WithCacheMode(ctx, CacheBypass), 2},
+		{WithCacheMode(ctx, CacheRefresh), 3},
+		{ctx, 3},
+	} {
+		resp, err := c.Character(1009351).GetContext(tc.ctx)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got := *resp.Data.Results[0].ID; got != 1009351 {
+			t.Errorf("got ID %d, want 1009351", got)
+		}
+		if calls != tc.wantCalls {
+			t.Errorf("got %d calls, want %d", calls, tc.wantCalls)
+		}
+	}
+}
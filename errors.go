@@ -0,0 +1,102 @@
+package marvel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Sentinel errors that an *APIError can be matched against using errors.Is.
+var (
+	// ErrInvalidCredentials indicates that the API key, hash or timestamp was
+	// missing or rejected.
+	ErrInvalidCredentials = errors.New("marvel: invalid credentials")
+	// ErrForbidden indicates that the API key is not authorized for the request.
+	ErrForbidden = errors.New("marvel: forbidden")
+	// ErrNotFound indicates that the requested entity does not exist.
+	ErrNotFound = errors.New("marvel: not found")
+	// ErrInvalidParameter indicates that a request parameter was missing or invalid.
+	ErrInvalidParameter = errors.New("marvel: invalid parameter")
+	// ErrRateLimited indicates that the API key has exceeded its rate limit.
+	ErrRateLimited = errors.New("marvel: rate limited")
+)
+
+// APIError represents an error response returned by the API.
+//
+// See http://developer.marvel.com/documentation/authorization and
+// http://developer.marvel.com/docs for the documented error codes.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the error code reported by the API, e.g. "InvalidCredentials"
+	// or "409". It may be empty if the response body could not be decoded.
+	Code string
+	// Message is the human-readable error message reported by the API.
+	Message string
+	// Path is the request path and query, without authentication parameters.
+	Path string
+	// Header holds the response headers.
+	Header http.Header
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Code != "" && e.Code != strconv.Itoa(e.StatusCode) {
+		return fmt.Sprintf("marvel: %s: %d %s: %s", e.Path, e.StatusCode, e.Code, msg)
+	}
+	return fmt.Sprintf("marvel: %s: %d: %s", e.Path, e.StatusCode, msg)
+}
+
+// Is reports whether the error matches one of the package's sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidCredentials:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrInvalidParameter:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// newAPIError constructs an *APIError from an error response.
+//
+// The API reports errors as either {"code": 409, "status": "..."} or
+// {"code": "InvalidCredentials", "message": "..."}; both forms are accepted.
+func newAPIError(statusCode int, path string, header http.Header, body []byte) *APIError {
+	e := &APIError{StatusCode: statusCode, Path: path, Header: header, Body: body}
+	var v struct {
+		Code    json.RawMessage `json:"code"`
+		Status  string          `json:"status"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return e
+	}
+	var s string
+	if err := json.Unmarshal(v.Code, &s); err == nil {
+		e.Code = s
+	} else {
+		var n int
+		if err := json.Unmarshal(v.Code, &n); err == nil {
+			e.Code = strconv.Itoa(n)
+		}
+	}
+	e.Message = v.Message
+	if e.Message == "" {
+		e.Message = v.Status
+	}
+	return e
+}
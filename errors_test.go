@@ -0,0 +1,65 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	for _, tc := range []struct {
+		status   int
+		body     string
+		wantCode string
+		wantMsg  string
+		want     error
+	}{{
+		status:   http.StatusUnauthorized,
+		body:     `{"code":"InvalidCredentials","message":"The passed API key is invalid."}`,
+		wantCode: "InvalidCredentials",
+		wantMsg:  "The passed API key is invalid.",
+		want:     ErrInvalidCredentials,
+	}, {
+		status:   http.StatusConflict,
+		body:     `{"code":409,"status":"You must provide a user key."}`,
+		wantCode: "409",
+		wantMsg:  "You must provide a user key.",
+		want:     ErrInvalidParameter,
+	}, {
+		status:   http.StatusTooManyRequests,
+		body:     `{"code":"RequestThrottled","message":"You have exceeded your rate limit."}`,
+		wantCode: "RequestThrottled",
+		wantMsg:  "You have exceeded your rate limit.",
+		want:     ErrRateLimited,
+	}, {
+		status: http.StatusNotFound,
+		body:   `not json`,
+		want:   ErrNotFound,
+	}} {
+		c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		}))
+		_, err := c.CharactersContext(context.Background(), CharactersParams{Name: "Hulk"})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got error %v, want *APIError", err)
+		}
+		if apiErr.StatusCode != tc.status {
+			t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tc.status)
+		}
+		if apiErr.Code != tc.wantCode {
+			t.Errorf("Code = %q, want %q", apiErr.Code, tc.wantCode)
+		}
+		if apiErr.Message != tc.wantMsg {
+			t.Errorf("Message = %q, want %q", apiErr.Message, tc.wantMsg)
+		}
+		if want := "/v1/public/characters?name=Hulk"; apiErr.Path != want {
+			t.Errorf("Path = %q, want %q", apiErr.Path, want)
+		}
+		if !errors.Is(err, tc.want) {
+			t.Errorf("errors.Is(%v, %v) = false", err, tc.want)
+		}
+	}
+}
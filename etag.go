@@ -0,0 +1,74 @@
+package marvel
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// ETagStore remembers the ETag and body of previous responses, so that
+// repeated requests can be made conditionally with If-None-Match.
+//
+// Keys are request URLs without authentication parameters.
+type ETagStore interface {
+	// Get returns the ETag and body stored for key, if any.
+	Get(key string) (etag string, body []byte, ok bool)
+	// Set stores the ETag and body of a response for key.
+	Set(key, etag string, body []byte)
+}
+
+// MemoryETagStore is an ETagStore that keeps every response in memory.
+// The zero value is ready to use.
+type MemoryETagStore struct {
+	mu sync.Mutex
+	m  map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+// Get implements ETagStore.
+func (s *MemoryETagStore) Get(key string) (string, []byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.m[key]
+	return e.etag, e.body, ok
+}
+
+// Set implements ETagStore.
+func (s *MemoryETagStore) Set(key, etag string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = map[string]etagEntry{}
+	}
+	s.m[key] = etagEntry{etag, body}
+}
+
+// responseETag returns the ETag of a response, preferring the ETag header and
+// falling back to the "etag" field of the body.
+func responseETag(header string, body []byte) string {
+	if header != "" {
+		return header
+	}
+	var v struct {
+		ETag string `json:"etag"`
+	}
+	json.Unmarshal(body, &v)
+	return v.ETag
+}
+
+// commonResponse returns the CommonResponse embedded in the value out points
+// to, or nil if there is none.
+func commonResponse(out interface{}) *CommonResponse {
+	v := reflect.ValueOf(out)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		if r, ok := v.Interface().(interface{ common() *CommonResponse }); ok {
+			return r.common()
+		}
+		v = v.Elem()
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestETags(t *testing.T) {
+	const etag = "f0fbae65eb2f8f28bdeea0a29be8749a4e67acb3"
+	var conditional int
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"code":200,"etag":"` + etag + `","data":{"results":[{"id":1009351,"name":"Hulk"}]}}`))
+	}))
+	c.ETags = &MemoryETagStore{}
+
+	ctx := context.Background()
+	for i, wantNotModified := range []bool{false, true, true} {
+		resp, err := c.CharactersContext(ctx, CharactersParams{Name: "Hulk"})
+		if err != nil {
+			t.Fatalf("CharactersContext: %v", err)
+		}
+		if resp.NotModified != wantNotModified {
+			t.Errorf("request %d: NotModified = %t, want %t", i, resp.NotModified, wantNotModified)
+		}
+		if got := *resp.Data.Results[0].Name; got != "Hulk" {
+			t.Errorf("request %d: got name %q, want Hulk", i, got)
+		}
+	}
+	if conditional != 2 {
+		t.Errorf("got %d conditional requests, want 2", conditional)
+	}
+
+	// A request with different parameters is not conditional.
+	if resp, err := c.CharactersContext(ctx, CharactersParams{Name: "Thor"}); err != nil {
+		t.Fatalf("CharactersContext: %v", err)
+	} else if resp.NotModified {
+		t.Errorf("NotModified set for a new request")
+	}
+}
+
+// etagOnlyStore is an ETagStore that remembers ETags but not bodies.
+type etagOnlyStore struct{ etag string }
+
+func (s etagOnlyStore) Get(string) (string, []byte, bool) { return s.etag, nil, true }
+func (s etagOnlyStore) Set(string, string, []byte)        {}
+
+func TestUnexpectedNotModified(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if inm := r.Header.Get("If-None-Match"); inm != "" {
+			t.Errorf("got If-None-Match %q without a stored body", inm)
+		}
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	c.ETags = etagOnlyStore{"abc"}
+
+	_, err := c.CharactersContext(context.Background(), CharactersParams{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotModified {
+		t.Errorf("CharactersContext() = %v, want *APIError with status 304", err)
+	}
+}
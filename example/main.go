@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"image"
@@ -34,35 +35,22 @@ func main() {
 		PrivateKey: *secret,
 	}
 
-	offset := 0
-	limit := 100
 	imgs := []image.Image{}
-	for {
-		r, err := c.SingleSeries(*seriesID).Comics(marvel.ComicsParams{
-			CommonParams: marvel.CommonParams{
-				Offset:  offset,
-				Limit:   limit,
-				OrderBy: "onsaleDate",
-			},
-		})
+	comics := c.SingleSeries(*seriesID).AllComics(context.Background(), marvel.ComicsParams{
+		OrderBy: []marvel.ComicOrder{marvel.ComicOrderOnSaleDate},
+	}, marvel.PageOptions{Concurrency: 4})
+	for iss, err := range comics {
 		if err != nil {
-			log.Fatal("Getting comics (offset=%d)", offset)
+			log.Fatalf("Getting comics: %v", err)
 		}
-		fmt.Print(".")
-		for _, iss := range r.Data.Results {
-			url := iss.Thumbnail.URL(marvel.PortraitIncredible)
-			img := fetchImage(url)
-			if img != nil {
-				imgs = append(imgs, img)
-				fmt.Printf("fetched %v - %s\n", *iss.IssueNumber, url)
-			} else {
-				fmt.Printf("skipped %s\n", url)
-			}
+		url := iss.Thumbnail.URL(marvel.PortraitIncredible)
+		img := fetchImage(url)
+		if img != nil {
+			imgs = append(imgs, img)
+			fmt.Printf("fetched %v - %s\n", *iss.IssueNumber, url)
+		} else {
+			fmt.Printf("skipped %s\n", url)
 		}
-		if len(r.Data.Results) < limit {
-			break
-		}
-		offset += limit
 	}
 
 	if err := writeGIF(fmt.Sprintf("%d.gif", *seriesID), imgs); err != nil {
@@ -1,6 +1,6 @@
 module github.com/imjasonh/go-marvel
 
-go 1.18
+go 1.23
 
 require (
 	github.com/ImJasonH/go-marvel v0.0.0-20140507165806-e50bba31c58d
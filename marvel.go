@@ -1,94 +1,312 @@
 package marvel
 
 import (
-	"crypto/md5"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
+	"iter"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 const (
+	// DefaultBaseURL is the base URL of the API used if Client.BaseURL is empty.
+	DefaultBaseURL = "https://gateway.marvel.com/v1/public"
+
+	// basePath is the prefix of resource and collection URIs returned by the API.
 	basePath = "http://gateway.marvel.com/v1/public"
 )
 
 // Client provides methods to get Marvel Comics data.
 type Client struct {
+	// PublicKey and PrivateKey are the API keys requests are authenticated
+	// with. If PrivateKey is empty, requests are authenticated with only
+	// the PublicKey and Referer, as in browser-side applications, which
+	// need not embed the private key.
 	PublicKey, PrivateKey string
-	Client                *http.Client
+	// Referer, if not empty, is sent as the Referer header of each request.
+	// It must be authorized for the PublicKey if PrivateKey is empty. In
+	// browsers, including WebAssembly builds, the browser sets the Referer
+	// header itself and this is not needed.
+	Referer string
+
+	// Client, if not nil, is used to make requests. Its Transport is wrapped
+	// by a Signer that authenticates them.
+	Client *http.Client
+	// Signer, if not nil, authenticates requests in place of a Signer using
+	// PublicKey, PrivateKey and Referer, e.g. to set its Now or Timestamp so
+	// that signatures are deterministic in tests. If its Transport is nil,
+	// the Transport of Client is used.
+	Signer *Signer
+
+	// BaseURL is the base URL of the API, e.g. a local mock server or
+	// caching proxy. If empty, DefaultBaseURL is used.
+	BaseURL string
+
+	// Retry configures how failed requests are retried. If nil, each request
+	// is attempted once.
+	Retry *RetryPolicy
+	// Limiter, if not nil, is waited on before every request attempt.
+	Limiter Limiter
+	// ETags, if not nil, is used to make conditional requests for
+	// previously seen responses. Responses that were not modified are
+	// decoded from the stored body and have NotModified set.
+	ETags ETagStore
+	// Cache, if not nil, stores successful responses and serves repeated
+	// requests without calling the API. Use WithCacheMode to bypass or
+	// refresh the cache for a single request.
+	Cache Cache
+	// Strict, if not nil, checks each response for fields that are not
+	// decoded into the typed structs.
+	Strict *StrictDecoding
+}
+
+func (c Client) fetch(ctx context.Context, path string, params interface{}, out interface{}) error {
+	if v, ok := params.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	u, err := c.baseURL(path, params)
+	if err != nil {
+		return err
+	}
+	key := u.String()
+	mode := cacheMode(ctx)
+	if c.Cache != nil && mode == CacheDefault {
+		if body, ok := c.Cache.Get(key); ok {
+			if raw := rawResponse(ctx); raw != nil {
+				*raw = RawResponse{Body: body, Cached: true}
+			}
+			return c.decode(u, body, out)
+		}
+	}
+
+	var body []byte
+	var notModified bool
+	for attempt := 1; ; attempt++ {
+		var err error
+		body, notModified, err = c.do(ctx, u)
+		if err == nil {
+			break
+		}
+		d, ok := c.Retry.backoff(attempt, err)
+		if !ok {
+			return err
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	if c.Cache != nil && mode != CacheBypass {
+		c.Cache.Set(key, body)
+	}
+	if err := c.decode(u, body, out); err != nil {
+		return err
+	}
+	if notModified {
+		if r := commonResponse(out); r != nil {
+			r.NotModified = true
+		}
+	}
+	return nil
 }
 
-func (c Client) fetch(path string, params interface{}, out interface{}) error {
-	u := c.baseURL(path, params)
-	if u.RawQuery != "" {
-		u.RawQuery += "&"
+// decode decodes the response body for the request u into out.
+func (c Client) decode(u url.URL, body []byte, out interface{}) error {
+	if err := json.Unmarshal(body, out); err != nil {
+		return err
 	}
-	ts, hash := c.hash()
-	u.RawQuery += url.Values(map[string][]string{
-		"ts":     []string{fmt.Sprintf("%d", ts)},
-		"apikey": []string{c.PublicKey},
-		"hash":   []string{hash},
-	}).Encode()
-	if c.Client == nil {
-		c.Client = &http.Client{}
+	return c.Strict.check(u.RequestURI(), body, out)
+}
+
+// do makes a single signed request for u and returns the response body. If
+// the response was not modified since a previous response in the Client's
+// ETagStore, that response's body is returned instead.
+func (c Client) do(ctx context.Context, u url.URL) (body []byte, notModified bool, err error) {
+	if c.Limiter != nil {
+		if err := c.Limiter.Wait(ctx); err != nil {
+			return nil, false, err
+		}
 	}
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
-	resp, err := c.Client.Do(req)
+	key := u.String()
+	var cached []byte
+	if c.ETags != nil {
+		if etag, b, ok := c.ETags.Get(key); ok && etag != "" && len(b) > 0 {
+			req.Header.Set("If-None-Match", etag)
+			cached = b
+		}
+	}
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
-		return err
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	if raw := rawResponse(ctx); raw != nil {
+		*raw = RawResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		if cached == nil {
+			// The request was not conditional, so there is nothing to decode.
+			err := newAPIError(resp.StatusCode, u.RequestURI(), resp.Header, body)
+			err.Message = "unexpected Not Modified response to an unconditional request"
+			return nil, false, err
+		}
+		return cached, true, nil
 	}
 	if resp.StatusCode >= http.StatusBadRequest {
-		slurp, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return err
+		return nil, false, newAPIError(resp.StatusCode, u.RequestURI(), resp.Header, body)
+	}
+	if c.ETags != nil {
+		if etag := responseETag(resp.Header.Get("ETag"), body); etag != "" {
+			c.ETags.Set(key, etag, body)
 		}
-		return fmt.Errorf("error response from API: %d\n%s", resp.StatusCode, slurp)
 	}
-	defer resp.Body.Close()
-	return json.NewDecoder(resp.Body).Decode(out)
+	return body, false, nil
 }
 
-func (c Client) baseURL(path string, params interface{}) url.URL {
-	u := url.URL{
-		Scheme: "https",
-		Host:   "gateway.marvel.com",
-		Path:   "/v1/public" + path,
+// baseURL returns the unsigned URL for a request to the API.
+func (c Client) baseURL(path string, params interface{}) (url.URL, error) {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return url.URL{}, fmt.Errorf("marvel: parsing base URL: %v", err)
 	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
 	if params != nil {
-		q, _ := query.Values(params)
-		u.RawQuery += "&" + q.Encode()
+		q, err := query.Values(params)
+		if err != nil {
+			return url.URL{}, err
+		}
+		u.RawQuery = q.Encode()
+	}
+	return *u, nil
+}
+
+// fetchOne issues a request for the entity of type T at a resource URI.
+func fetchOne[T any](ctx context.Context, cl Client, uri *string) (*T, error) {
+	if uri == nil {
+		return nil, errors.New("marvel: missing resource URI")
+	}
+	resp, err := ResolveURI[T](ctx, cl, *uri)
+	if err != nil {
+		return nil, err
 	}
-	return u
+	if len(resp.Data.Results) == 0 {
+		return nil, fmt.Errorf("marvel: no result for %s: %w", *uri, ErrNotFound)
+	}
+	return &resp.Data.Results[0], nil
 }
 
-// See http://developer.marvel.com/documentation/authorization
-func (c Client) hash() (int64, string) {
-	ts := time.Now().Unix()
-	hash := md5.New()
-	io.WriteString(hash, fmt.Sprintf("%d%s%s", ts, c.PrivateKey, c.PublicKey))
-	return ts, fmt.Sprintf("%x", hash.Sum(nil))
+// httpClient returns the Client's http.Client, with a Transport that signs
+// requests using the Client's Signer, or one using its keys.
+func (c Client) httpClient() *http.Client {
+	var hc http.Client
+	if c.Client != nil {
+		hc = *c.Client
+	}
+	s := Signer{PublicKey: c.PublicKey, PrivateKey: c.PrivateKey, Referer: c.Referer}
+	if c.Signer != nil {
+		s = *c.Signer
+	}
+	if s.Transport == nil {
+		s.Transport = hc.Transport
+	}
+	hc.Transport = &s
+	return &hc
 }
 
 // URL represents a public web site URL for a resource.
 type URL struct {
-	Type *string `json:"type,omitempty"`
-	URL  *string `json:"url,omitempty"`
+	Type *URLType `json:"type,omitempty"`
+	URL  *string  `json:"url,omitempty"`
+}
+
+// URLType is the kind of page a URL links to.
+type URLType string
+
+// Types of URLs.
+const (
+	URLDetail    URLType = "detail"
+	URLWiki      URLType = "wiki"
+	URLComicLink URLType = "comiclink"
+	URLPurchase  URLType = "purchase"
+	URLReader    URLType = "reader"
+)
+
+// findURL returns the first URL of type t in urls, or "" if there is none.
+func findURL(urls []URL, t URLType) string {
+	for _, u := range urls {
+		if u.Type != nil && *u.Type == t && u.URL != nil {
+			return *u.URL
+		}
+	}
+	return ""
 }
 
 // CommonParams provides fields common to all request parameter entities.
+//
+// Each entity's parameters also have an OrderBy field, typed by the orderings
+// that entity supports.
 type CommonParams struct {
-	OrderBy       string `url:"orderBy,omitempty"`
-	Offset        int    `url:"offset,omitempty"`
-	Limit         int    `url:"limit,omitempty"`
-	ModifiedSince string `url:"modifiedSince,omitempty"`
+	Offset        int       `url:"offset,omitempty"`
+	Limit         int       `url:"limit,omitempty"`
+	ModifiedSince time.Time `url:"modifiedSince,omitempty" layout:"2006-01-02T15:04:05-0700"`
+}
+
+// DateRange is a range of dates, inclusive, used to filter Comics by their
+// on-sale date.
+type DateRange struct {
+	Start, End time.Time
+}
+
+const dateRangeLayout = "2006-01-02"
+
+// IsZero reports whether the DateRange is unset.
+func (r DateRange) IsZero() bool {
+	return r.Start.IsZero() && r.End.IsZero()
+}
+
+// EncodeValues implements query.Encoder, encoding the range as two
+// comma-separated dates.
+func (r DateRange) EncodeValues(key string, v *url.Values) error {
+	if r.IsZero() {
+		return nil
+	}
+	if err := r.check(); err != nil {
+		return fmt.Errorf("marvel: %s %v", key, err)
+	}
+	v.Set(key, r.Start.Format(dateRangeLayout)+","+r.End.Format(dateRangeLayout))
+	return nil
+}
+
+// check reports whether a non-zero range has both ends, in order.
+func (r DateRange) check() error {
+	if r.Start.IsZero() || r.End.IsZero() {
+		return errors.New("must have both a start and an end")
+	}
+	if r.End.Before(r.Start) {
+		return fmt.Errorf("start %s is after end %s", r.Start.Format(dateRangeLayout), r.End.Format(dateRangeLayout))
+	}
+	return nil
 }
 
 // CommonResponse provides fields common to all response entities.
@@ -98,7 +316,50 @@ type CommonResponse struct {
 	Status          *string `json:"status,omitempty"`
 	Copyright       *string `json:"copyright,omitempty"`
 	AttributionText *string `json:"attributionText,omitempty"`
-	AttributionHTML *string `json:"attributionHtml,omitempty"`
+	AttributionHTML *string `json:"attributionHTML,omitempty"`
+
+	// NotModified is set if the response was served from the Client's
+	// ETagStore because the API reported it was not modified.
+	NotModified bool `json:"-"`
+}
+
+func (r *CommonResponse) common() *CommonResponse { return r }
+
+// Response represents responses to methods that return entities of type T.
+type Response[T any] struct {
+	CommonResponse
+	Data DataContainer[T] `json:"data,omitempty"`
+}
+
+// DataContainer holds the results of a response, with pagination.
+type DataContainer[T any] struct {
+	CommonList
+	Results []T `json:"results,omitempty"`
+}
+
+// Resource provides methods to issue requests for a single entity of type T.
+type Resource[T any] struct {
+	basePath string
+	client   Client
+}
+
+// Get issues a request to get the entity.
+func (r Resource[T]) Get() (*Response[T], error) {
+	return r.GetContext(context.Background())
+}
+
+// GetContext is like Get, with ctx controlling cancellation and deadlines.
+func (r Resource[T]) GetContext(ctx context.Context) (*Response[T], error) {
+	return get[T](ctx, r.client, r.basePath, nil)
+}
+
+// get issues a request for the entities of type T at path matching params.
+func get[T any](ctx context.Context, c Client, path string, params interface{}) (*Response[T], error) {
+	var resp *Response[T]
+	if err := c.fetch(ctx, path, params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 // CommonList provides fields common to data that lists entities, with pagination.
@@ -114,7 +375,13 @@ type CommonList struct {
 type ResourceList struct {
 	Available     *int    `json:"available,omitempty"`
 	Returned      *int    `json:"returned,omitempty"`
-	CollectionURI *string `json:"collectionUri,omitempty"`
+	CollectionURI *string `json:"collectionURI,omitempty"`
+}
+
+// Truncated reports whether the list's items are only some of the entities
+// available in its collection.
+func (l ResourceList) Truncated() bool {
+	return l.Returned != nil && l.Available != nil && *l.Returned < *l.Available
 }
 
 // Image provides data necessary to construct an image URL.
@@ -151,17 +418,106 @@ func (i Image) URL(v Variant) string {
 	return fmt.Sprintf("%s/%s.%s", *i.Path, string(v), *i.Extension)
 }
 
-type Date string
+// Date is a date and time returned by the API.
+//
+// The API represents unknown dates with sentinel values such as
+// "-0001-11-30T00:00:00-0500", "1969-12-31T19:00:00-0500" (the Unix epoch)
+// or the empty string; these decode to a Date with a zero Time, for which
+// IsKnown reports false, and encode back to the original value.
+type Date struct {
+	time.Time
 
-const dateLayout = "2006-01-02T15:04:05-0700"
+	// raw is the original value of an unknown date.
+	raw string
+	// empty is set if the original value was the empty string, which is
+	// encoded as such rather than as null.
+	empty bool
+	// layout is the layout a known date was parsed with, if not dateLayout.
+	layout string
+}
 
-// Parse returns a time.Time equivalent to the Date.
+const (
+	dateLayout = "2006-01-02T15:04:05-0700"
+	// eventDateLayout is the layout of Event start and end dates, which have
+	// no time zone.
+	eventDateLayout = "2006-01-02 15:04:05"
+)
+
+// ParseDate parses a date in the format returned by the API.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{empty: true}, nil
+	}
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "0000") {
+		return Date{raw: s}, nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	layout := ""
+	if err != nil {
+		for _, l := range []string{eventDateLayout, time.RFC3339} {
+			if t2, err2 := time.Parse(l, s); err2 == nil {
+				t, layout, err = t2, l, nil
+				break
+			}
+		}
+		if err != nil {
+			return Date{}, fmt.Errorf("marvel: invalid date %q: %v", s, err)
+		}
+	}
+	if t.Unix() == 0 {
+		return Date{raw: s}, nil
+	}
+	return Date{Time: t, layout: layout}, nil
+}
+
+// IsKnown reports whether the Date holds a known date.
+func (d Date) IsKnown() bool {
+	return !d.Time.IsZero()
+}
+
+// Parse returns a time.Time equivalent to the Date, which is the zero time
+// if the date is unknown.
+//
+// Deprecated: Use the Time field.
 func (d Date) Parse() time.Time {
-	t, err := time.Parse(dateLayout, string(d))
+	return d.Time
+}
+
+// String returns the Date in the format returned by the API.
+func (d Date) String() string {
+	if !d.IsKnown() {
+		return d.raw
+	}
+	if d.layout != "" {
+		return d.Time.Format(d.layout)
+	}
+	return d.Time.Format(dateLayout)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.IsKnown() && d.raw == "" && !d.empty {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("marvel: invalid date %s", b)
+	}
+	parsed, err := ParseDate(s)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	return t
+	*d = parsed
+	return nil
 }
 
 /////
@@ -170,69 +526,114 @@ func (d Date) Parse() time.Time {
 
 // Character begins to construct a request for information based on a Character.
 func (c Client) Character(id int) CharacterResource {
-	return CharacterResource{basePath: fmt.Sprintf("/characters/%d", id), client: c}
+	return CharacterResource{Resource[Character]{basePath: fmt.Sprintf("/characters/%d", id), client: c}}
 }
 
 // CharacterResource provides methods to issue requests for a Character.
 type CharacterResource struct {
-	basePath string
-	client   Client
+	Resource[Character]
 }
 
 // Characters issues a request to search for Characters.
-func (c Client) Characters(params CharactersParams) (resp *CharactersResponse, err error) {
-	err = c.fetch("/characters", params, &resp)
-	return
+func (c Client) Characters(params CharactersParams) (*CharactersResponse, error) {
+	return c.CharactersContext(context.Background(), params)
+}
+
+// CharactersContext is like Characters, with ctx controlling cancellation and deadlines.
+func (c Client) CharactersContext(ctx context.Context, params CharactersParams) (*CharactersResponse, error) {
+	return get[Character](ctx, c, "/characters", params)
 }
 
-// Get issues a request to get a Character.
-func (s CharacterResource) Get() (resp *CharactersResponse, err error) {
-	err = s.client.fetch(s.basePath, nil, &resp)
-	return
+// AllCharacters returns an iterator over all Characters matching params, fetching pages as needed.
+func (c Client) AllCharacters(ctx context.Context, params CharactersParams, opts PageOptions) iter.Seq2[Character, error] {
+	return listAll[Character](ctx, c, "/characters", params, opts)
 }
 
 // Comics issues a request to search for Comics associated with a Character.
-func (s CharacterResource) Comics(params ComicsParams) (resp *ComicsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/comics", params, &resp)
-	return
+func (s CharacterResource) Comics(params ComicsParams) (*ComicsResponse, error) {
+	return s.ComicsContext(context.Background(), params)
+}
+
+// ComicsContext is like Comics, with ctx controlling cancellation and deadlines.
+func (s CharacterResource) ComicsContext(ctx context.Context, params ComicsParams) (*ComicsResponse, error) {
+	return get[Comic](ctx, s.client, s.basePath+"/comics", params)
+}
+
+// AllComics returns an iterator over all Comics associated with a Character, fetching pages as needed.
+func (s CharacterResource) AllComics(ctx context.Context, params ComicsParams, opts PageOptions) iter.Seq2[Comic, error] {
+	return listAll[Comic](ctx, s.client, s.basePath+"/comics", params, opts)
 }
 
 // Events issues a request to search for Events associated with a Character.
-func (s CharacterResource) Events(params EventsParams) (resp *EventsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/events", params, &resp)
-	return
+func (s CharacterResource) Events(params EventsParams) (*EventsResponse, error) {
+	return s.EventsContext(context.Background(), params)
+}
+
+// EventsContext is like Events, with ctx controlling cancellation and deadlines.
+func (s CharacterResource) EventsContext(ctx context.Context, params EventsParams) (*EventsResponse, error) {
+	return get[Event](ctx, s.client, s.basePath+"/events", params)
+}
+
+// AllEvents returns an iterator over all Events associated with a Character, fetching pages as needed.
+func (s CharacterResource) AllEvents(ctx context.Context, params EventsParams, opts PageOptions) iter.Seq2[Event, error] {
+	return listAll[Event](ctx, s.client, s.basePath+"/events", params, opts)
 }
 
 // Series issues a request to search for Series associated with a Character.
-func (s CharacterResource) Series(params SeriesParams) (resp *SeriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/series", params, &resp)
-	return
+func (s CharacterResource) Series(params SeriesParams) (*SeriesResponse, error) {
+	return s.SeriesContext(context.Background(), params)
+}
+
+// SeriesContext is like Series, with ctx controlling cancellation and deadlines.
+func (s CharacterResource) SeriesContext(ctx context.Context, params SeriesParams) (*SeriesResponse, error) {
+	return get[Series](ctx, s.client, s.basePath+"/series", params)
+}
+
+// AllSeries returns an iterator over all Series associated with a Character, fetching pages as needed.
+func (s CharacterResource) AllSeries(ctx context.Context, params SeriesParams, opts PageOptions) iter.Seq2[Series, error] {
+	return listAll[Series](ctx, s.client, s.basePath+"/series", params, opts)
 }
 
 // Stories issues a request to search for Stories associated with a Character.
-func (s CharacterResource) Stories(params StoriesParams) (resp *StoriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/stories", params, &resp)
-	return
+func (s CharacterResource) Stories(params StoriesParams) (*StoriesResponse, error) {
+	return s.StoriesContext(context.Background(), params)
+}
+
+// StoriesContext is like Stories, with ctx controlling cancellation and deadlines.
+func (s CharacterResource) StoriesContext(ctx context.Context, params StoriesParams) (*StoriesResponse, error) {
+	return get[Story](ctx, s.client, s.basePath+"/stories", params)
+}
+
+// AllStories returns an iterator over all Stories associated with a Character, fetching pages as needed.
+func (s CharacterResource) AllStories(ctx context.Context, params StoriesParams, opts PageOptions) iter.Seq2[Story, error] {
+	return listAll[Story](ctx, s.client, s.basePath+"/stories", params, opts)
 }
 
 // CharactersParams represents parameters to search for Characters.
 type CharactersParams struct {
 	CommonParams
-	Name           string `url:"name,omitempty"`
-	NameStartsWith string `url:"nameStartsWith,omitempty"`
-	Comics         []int  `url:"comics,omitempty,comma"`
-	Events         []int  `url:"events,omitempty,comma"`
-	Stories        []int  `url:"stories,omitempty,comma"`
+	OrderBy        []CharacterOrder `url:"orderBy,omitempty,comma"`
+	Name           string           `url:"name,omitempty"`
+	NameStartsWith string           `url:"nameStartsWith,omitempty"`
+	Comics         []int            `url:"comics,omitempty,comma"`
+	Events         []int            `url:"events,omitempty,comma"`
+	Stories        []int            `url:"stories,omitempty,comma"`
 }
 
+// CharacterOrder is a field by which Characters can be ordered.
+// Orderings ending in Desc sort in descending order.
+type CharacterOrder string
+
+// Orderings for Characters.
+const (
+	CharacterOrderName         CharacterOrder = "name"
+	CharacterOrderNameDesc     CharacterOrder = "-name"
+	CharacterOrderModified     CharacterOrder = "modified"
+	CharacterOrderModifiedDesc CharacterOrder = "-modified"
+)
+
 // CharactersResponse represents responses to methods that return Characters.
-type CharactersResponse struct {
-	CommonResponse
-	Data struct {
-		CommonList
-		Results []Character `json:"results,omitempty"`
-	} `json:"data,omitempty"`
-}
+type CharactersResponse = Response[Character]
 
 // Character represents a single Character.
 type Character struct {
@@ -247,24 +648,95 @@ type Character struct {
 	Stories     *StoriesList `json:"stories,omitempty"`
 	Events      *EventsList  `json:"events,omitempty"`
 	Series      *SeriesList  `json:"series,omitempty"`
+
+	// Extra holds the fields returned by the API that are not otherwise
+	// decoded, by name.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Get issues a request to get complete information about a Character.
-func (c Character) Get(cl Client) (resp *CharactersResponse, err error) {
-	err = cl.fetch((*c.ResourceURI)[len(basePath):], nil, &resp)
-	return
+func (c Character) Get(cl Client) (*CharactersResponse, error) {
+	return c.GetContext(context.Background(), cl)
+}
+
+// GetContext is like Get, with ctx controlling cancellation and deadlines.
+func (c Character) GetContext(ctx context.Context, cl Client) (*CharactersResponse, error) {
+	path, err := cl.resourcePath(c.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Character](ctx, cl, path, nil)
+}
+
+// DetailURL returns the URL of the Character's page on marvel.com, or "" if
+// there is none.
+func (c Character) DetailURL() string {
+	return findURL(c.URLs, URLDetail)
+}
+
+// WikiURL returns the URL of the Character's wiki page, or "" if there is none.
+func (c Character) WikiURL() string {
+	return findURL(c.URLs, URLWiki)
+}
+
+// ComicLinkURL returns the URL of the Character's comics on marvel.com, or ""
+// if there is none.
+func (c Character) ComicLinkURL() string {
+	return findURL(c.URLs, URLComicLink)
+}
+
+// CharacterSummary is the minimal representation of a Character included in lists
+// and references from other entities. Use Fetch to get complete information.
+type CharacterSummary struct {
+	ResourceURI *string `json:"resourceURI,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	// Role is the role of the character in the parent entity, if any.
+	Role *string `json:"role,omitempty"`
+}
+
+// ID returns the ID of the Character, from its resource URI.
+func (s CharacterSummary) ID() (int, error) {
+	return uriID(s.ResourceURI)
+}
+
+// Fetch issues a request to get complete information about the Character.
+func (s CharacterSummary) Fetch(ctx context.Context, cl Client) (*Character, error) {
+	return fetchOne[Character](ctx, cl, s.ResourceURI)
 }
 
 // CharactersList represents a list of Characters.
 type CharactersList struct {
 	ResourceList
-	Items []Character `json:"items,omitempty"`
+	Items []CharacterSummary `json:"items,omitempty"`
 }
 
 // List issues a request to get complete information about a list of Characters.
-func (l CharactersList) List(cl Client) (resp *CharactersResponse, err error) {
-	err = cl.fetch((*l.CollectionURI)[len(basePath):], nil, &resp)
-	return
+func (l CharactersList) List(cl Client) (*CharactersResponse, error) {
+	return l.ListContext(context.Background(), cl)
+}
+
+// ListContext is like List, with ctx controlling cancellation and deadlines.
+func (l CharactersList) ListContext(ctx context.Context, cl Client) (*CharactersResponse, error) {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Character](ctx, cl, path, nil)
+}
+
+// All returns an iterator over the complete Characters in the list's collection
+// matching params, fetching pages as needed.
+func (l CharactersList) All(ctx context.Context, cl Client, params CharactersParams, opts PageOptions) iter.Seq2[Character, error] {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return fail[Character](err)
+	}
+	return listAll[Character](ctx, cl, path, params, opts)
+}
+
+// Expand returns every complete Character in the list's collection matching params.
+func (l CharactersList) Expand(ctx context.Context, cl Client, params CharactersParams) ([]Character, error) {
+	return collect(l.All(ctx, cl, params, PageOptions{}))
 }
 
 /////
@@ -273,143 +745,372 @@ func (l CharactersList) List(cl Client) (resp *CharactersResponse, err error) {
 
 // Comic begins to construct a request for information based on a Comic.
 func (c Client) Comic(id int) ComicResource {
-	return ComicResource{basePath: fmt.Sprintf("comics/%d", id), client: c}
+	return ComicResource{Resource[Comic]{basePath: fmt.Sprintf("/comics/%d", id), client: c}}
 }
 
 // ComicResource provides methods to issue requests for a Comic.
 type ComicResource struct {
-	basePath string
-	client   Client
+	Resource[Comic]
 }
 
 // Comics issues a request to search for Comics.
-func (c Client) Comics(params ComicsParams) (resp *ComicsResponse, err error) {
-	err = c.fetch("/comics", params, &resp)
-	return
+func (c Client) Comics(params ComicsParams) (*ComicsResponse, error) {
+	return c.ComicsContext(context.Background(), params)
 }
 
-// Get issues a request to get a Comic.
-func (s ComicResource) Get() (resp *ComicsResponse, err error) {
-	err = s.client.fetch(s.basePath, nil, &resp)
-	return
+// ComicsContext is like Comics, with ctx controlling cancellation and deadlines.
+func (c Client) ComicsContext(ctx context.Context, params ComicsParams) (*ComicsResponse, error) {
+	return get[Comic](ctx, c, "/comics", params)
+}
+
+// AllComics returns an iterator over all Comics matching params, fetching pages as needed.
+func (c Client) AllComics(ctx context.Context, params ComicsParams, opts PageOptions) iter.Seq2[Comic, error] {
+	return listAll[Comic](ctx, c, "/comics", params, opts)
 }
 
 // Characters issues a request to search for Characters associated with a Comic.
-func (s ComicResource) Characters(params CharactersParams) (resp *CharactersResponse, err error) {
-	err = s.client.fetch(s.basePath+"/characters", params, &resp)
-	return
+func (s ComicResource) Characters(params CharactersParams) (*CharactersResponse, error) {
+	return s.CharactersContext(context.Background(), params)
+}
+
+// CharactersContext is like Characters, with ctx controlling cancellation and deadlines.
+func (s ComicResource) CharactersContext(ctx context.Context, params CharactersParams) (*CharactersResponse, error) {
+	return get[Character](ctx, s.client, s.basePath+"/characters", params)
+}
+
+// AllCharacters returns an iterator over all Characters associated with a Comic, fetching pages as needed.
+func (s ComicResource) AllCharacters(ctx context.Context, params CharactersParams, opts PageOptions) iter.Seq2[Character, error] {
+	return listAll[Character](ctx, s.client, s.basePath+"/characters", params, opts)
 }
 
 // Events issues a request to search for Events associated with a Comic.
-func (s ComicResource) Events(params EventsParams) (resp *EventsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/events", params, &resp)
-	return
+func (s ComicResource) Events(params EventsParams) (*EventsResponse, error) {
+	return s.EventsContext(context.Background(), params)
+}
+
+// EventsContext is like Events, with ctx controlling cancellation and deadlines.
+func (s ComicResource) EventsContext(ctx context.Context, params EventsParams) (*EventsResponse, error) {
+	return get[Event](ctx, s.client, s.basePath+"/events", params)
+}
+
+// AllEvents returns an iterator over all Events associated with a Comic, fetching pages as needed.
+func (s ComicResource) AllEvents(ctx context.Context, params EventsParams, opts PageOptions) iter.Seq2[Event, error] {
+	return listAll[Event](ctx, s.client, s.basePath+"/events", params, opts)
 }
 
 // Series issues a request to search for Series associated with a Comic.
-func (s ComicResource) Series(params SeriesParams) (resp *SeriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/series", params, &resp)
-	return
+func (s ComicResource) Series(params SeriesParams) (*SeriesResponse, error) {
+	return s.SeriesContext(context.Background(), params)
+}
+
+// SeriesContext is like Series, with ctx controlling cancellation and deadlines.
+func (s ComicResource) SeriesContext(ctx context.Context, params SeriesParams) (*SeriesResponse, error) {
+	return get[Series](ctx, s.client, s.basePath+"/series", params)
+}
+
+// AllSeries returns an iterator over all Series associated with a Comic, fetching pages as needed.
+func (s ComicResource) AllSeries(ctx context.Context, params SeriesParams, opts PageOptions) iter.Seq2[Series, error] {
+	return listAll[Series](ctx, s.client, s.basePath+"/series", params, opts)
 }
 
 // Stories issues a request to search for Stories associated with a Comic.
-func (s ComicResource) Stories(params StoriesParams) (resp *StoriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/stories", params, &resp)
-	return
+func (s ComicResource) Stories(params StoriesParams) (*StoriesResponse, error) {
+	return s.StoriesContext(context.Background(), params)
+}
+
+// StoriesContext is like Stories, with ctx controlling cancellation and deadlines.
+func (s ComicResource) StoriesContext(ctx context.Context, params StoriesParams) (*StoriesResponse, error) {
+	return get[Story](ctx, s.client, s.basePath+"/stories", params)
+}
+
+// AllStories returns an iterator over all Stories associated with a Comic, fetching pages as needed.
+func (s ComicResource) AllStories(ctx context.Context, params StoriesParams, opts PageOptions) iter.Seq2[Story, error] {
+	return listAll[Story](ctx, s.client, s.basePath+"/stories", params, opts)
 }
 
 // ComicsParams represents parameters to search for Comics.
 type ComicsParams struct {
 	CommonParams
-	Format            string `url:"format,omitempty"`
-	FormatType        string `url:"formatType,omitempty"`
-	NoVariants        bool   `url:"noVariants,omitempty"`
-	DateDescriptor    string `url:"dateDescriptor,omitempty"`
-	DateRange         string `url:"dateRange,omitempty"`
-	DiamondCode       string `url:"diamondCode,omitempty"`
-	DigitalID         string `url:"digitalId,omitempty"`
-	UPC               string `url:"upc,omitempty"`
-	ISBN              string `url:"isbn,omitempty"`
-	EAN               string `url:"ean,omitempty"`
-	ISSN              string `url:"issn,omitempty"`
-	HasDigitalIssue   bool   `url:"hasDigitalIssue,omitempty"`
-	Creators          []int  `url:"creators,omitempty,comma"`
-	Characters        []int  `url:"characters,omitempty,comma"`
-	Events            []int  `url:"events,omitempty,comma"`
-	Stories           []int  `url:"stories,omitempty,comma"`
-	SharedAppearances []int  `url:"sharedAppearances,omitempty,comma"`
-	Collaborators     []int  `url:"collaborators,omitempty,comma"`
-}
+	OrderBy           []ComicOrder   `url:"orderBy,omitempty,comma"`
+	Format            Format         `url:"format,omitempty"`
+	FormatType        FormatType     `url:"formatType,omitempty"`
+	NoVariants        bool           `url:"noVariants,omitempty"`
+	DateDescriptor    DateDescriptor `url:"dateDescriptor,omitempty"`
+	DateRange         DateRange      `url:"dateRange,omitempty"`
+	DiamondCode       string         `url:"diamondCode,omitempty"`
+	DigitalID         string         `url:"digitalId,omitempty"`
+	UPC               string         `url:"upc,omitempty"`
+	ISBN              string         `url:"isbn,omitempty"`
+	EAN               string         `url:"ean,omitempty"`
+	ISSN              string         `url:"issn,omitempty"`
+	HasDigitalIssue   bool           `url:"hasDigitalIssue,omitempty"`
+	Creators          []int          `url:"creators,omitempty,comma"`
+	Characters        []int          `url:"characters,omitempty,comma"`
+	Events            []int          `url:"events,omitempty,comma"`
+	Stories           []int          `url:"stories,omitempty,comma"`
+	SharedAppearances []int          `url:"sharedAppearances,omitempty,comma"`
+	Collaborators     []int          `url:"collaborators,omitempty,comma"`
+}
+
+// Format is the publication format of a Comic.
+type Format string
+
+// Formats of Comics.
+const (
+	FormatComic          Format = "comic"
+	FormatMagazine       Format = "magazine"
+	FormatTradePaperback Format = "trade paperback"
+	FormatHardcover      Format = "hardcover"
+	FormatDigest         Format = "digest"
+	FormatGraphicNovel   Format = "graphic novel"
+	FormatDigitalComic   Format = "digital comic"
+	FormatInfiniteComic  Format = "infinite comic"
+)
+
+// FormatType distinguishes single issues from collections.
+type FormatType string
+
+// Format types of Comics.
+const (
+	FormatTypeComic      FormatType = "comic"
+	FormatTypeCollection FormatType = "collection"
+)
+
+// DateDescriptor describes a predefined range of on-sale dates.
+type DateDescriptor string
+
+// Predefined date ranges for Comics.
+const (
+	LastWeek  DateDescriptor = "lastWeek"
+	ThisWeek  DateDescriptor = "thisWeek"
+	NextWeek  DateDescriptor = "nextWeek"
+	ThisMonth DateDescriptor = "thisMonth"
+)
+
+// ComicOrder is a field by which Comics can be ordered.
+// Orderings ending in Desc sort in descending order.
+type ComicOrder string
+
+// Orderings for Comics.
+const (
+	ComicOrderFocDate         ComicOrder = "focDate"
+	ComicOrderFocDateDesc     ComicOrder = "-focDate"
+	ComicOrderOnSaleDate      ComicOrder = "onsaleDate"
+	ComicOrderOnSaleDateDesc  ComicOrder = "-onsaleDate"
+	ComicOrderTitle           ComicOrder = "title"
+	ComicOrderTitleDesc       ComicOrder = "-title"
+	ComicOrderIssueNumber     ComicOrder = "issueNumber"
+	ComicOrderIssueNumberDesc ComicOrder = "-issueNumber"
+	ComicOrderModified        ComicOrder = "modified"
+	ComicOrderModifiedDesc    ComicOrder = "-modified"
+)
 
 // ComicsResponse represents responses to methods that return Comics.
-type ComicsResponse struct {
-	CommonResponse
-	Data struct {
-		CommonList
-		Results []Comic `json:"results,omitempty"`
-	} `json:"data,omitempty"`
-}
+type ComicsResponse = Response[Comic]
 
 // Comic represents a single Comic.
 type Comic struct {
-	ResourceURI        *string  `json:"resourceURI,omitempty"`
-	ID                 *int     `json:"id,omitempty"`
-	Name               *string  `json:"id,omitempty"`
-	DigitalID          *int     `json:"digitalId,omitempty"`
-	Title              *string  `json:"title,omitempty"`
-	IssueNumber        *float64 `json:"issueNumber,omitempty"`
-	VariantDescription *string  `json:"variantDescription,omitEmpty"`
-	Description        *string  `json:"description,omitempty"`
-	Modified           *Date    `json:"modified,omitempty"`
-	ISBN               *string  `json:"isbn,omitempty"`
-	UPC                *string  `json:"upc,omitempty"`
-	DiamondCode        *string  `json:"diamondCode,omitempty"`
-	EAN                *string  `json:"ean,omitempty"`
-	ISSN               *string  `json:"issn,omitempty"`
-	Format             *string  `json:"format,omitempty"`
-	PageCount          *int     `json:"pageCount,omitEmpty"`
-	TextObjects        []struct {
-		Type     string `json:"text,omitempty"`
-		Language string `json:"language,omitempty"`
-		Text     string `json:"text,omitempty"`
-	} `json:"textObjects,omitempty"`
-	URLs            []URL   `json:"urls,omitempty"`
-	Series          *Series `json:"series,omitempty"`
-	Variants        []Comic `json:"variants,omitempty"`
-	Collections     []Comic `json:"collections,omitempty"`
-	CollectedIssues []Comic `json:"collectedIssues,omitempty"`
-	Dates           []struct {
-		Type string `json:"type,omitempty"`
-		Date Date   `json:"date,omitempty"`
-	} `json:"dates,omitempty"`
-	Prices []struct {
-		Type  string  `json:"type,omitempty"`
-		Price float64 `json:"price,omitempty"`
-	} `json:"prices,omitempty"`
-	Thumbnail  *Image          `json:"thumbnail,omitempty"`
-	Images     []Image         `json:"images,omitempty"`
-	Creators   *CreatorsList   `json:"creators,omitempty"`
-	Characters *CharactersList `json:"characters,omitempty"`
-	Stories    *StoriesList    `json:"stories,omitempty"`
-	Events     *EventsList     `json:"events,omitempty"`
+	ResourceURI        *string         `json:"resourceURI,omitempty"`
+	ID                 *int            `json:"id,omitempty"`
+	Name               *string         `json:"name,omitempty"`
+	DigitalID          *int            `json:"digitalId,omitempty"`
+	Title              *string         `json:"title,omitempty"`
+	IssueNumber        *float64        `json:"issueNumber,omitempty"`
+	VariantDescription *string         `json:"variantDescription,omitempty"`
+	Description        *string         `json:"description,omitempty"`
+	Modified           *Date           `json:"modified,omitempty"`
+	ISBN               *string         `json:"isbn,omitempty"`
+	UPC                *string         `json:"upc,omitempty"`
+	DiamondCode        *string         `json:"diamondCode,omitempty"`
+	EAN                *string         `json:"ean,omitempty"`
+	ISSN               *string         `json:"issn,omitempty"`
+	Format             *string         `json:"format,omitempty"`
+	PageCount          *int            `json:"pageCount,omitempty"`
+	TextObjects        []TextObject    `json:"textObjects,omitempty"`
+	URLs               []URL           `json:"urls,omitempty"`
+	Series             *SeriesSummary  `json:"series,omitempty"`
+	Variants           []ComicSummary  `json:"variants,omitempty"`
+	Collections        []ComicSummary  `json:"collections,omitempty"`
+	CollectedIssues    []ComicSummary  `json:"collectedIssues,omitempty"`
+	Dates              []ComicDate     `json:"dates,omitempty"`
+	Prices             []ComicPrice    `json:"prices,omitempty"`
+	Thumbnail          *Image          `json:"thumbnail,omitempty"`
+	Images             []Image         `json:"images,omitempty"`
+	Creators           *CreatorsList   `json:"creators,omitempty"`
+	Characters         *CharactersList `json:"characters,omitempty"`
+	Stories            *StoriesList    `json:"stories,omitempty"`
+	Events             *EventsList     `json:"events,omitempty"`
+
+	// Extra holds the fields returned by the API that are not otherwise
+	// decoded, by name.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Get issues a request to get complete information about a Comic.
-func (c Comic) Get(cl Client) (resp *ComicsResponse, err error) {
-	err = cl.fetch((*c.ResourceURI)[len(basePath):], nil, &resp)
-	return
+func (c Comic) Get(cl Client) (*ComicsResponse, error) {
+	return c.GetContext(context.Background(), cl)
+}
+
+// GetContext is like Get, with ctx controlling cancellation and deadlines.
+func (c Comic) GetContext(ctx context.Context, cl Client) (*ComicsResponse, error) {
+	path, err := cl.resourcePath(c.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Comic](ctx, cl, path, nil)
+}
+
+// ComicDate represents a key date for a Comic.
+type ComicDate struct {
+	Type ComicDateType `json:"type,omitempty"`
+	Date Date          `json:"date"`
+}
+
+// ComicDateType is the kind of a ComicDate.
+type ComicDateType string
+
+// Types of ComicDates.
+const (
+	ComicDateOnSale          ComicDateType = "onsaleDate"
+	ComicDateFOC             ComicDateType = "focDate"
+	ComicDateUnlimited       ComicDateType = "unlimitedDate"
+	ComicDateDigitalPurchase ComicDateType = "digitalPurchaseDate"
+)
+
+// ComicPrice represents a price for a Comic.
+type ComicPrice struct {
+	Type  ComicPriceType `json:"type,omitempty"`
+	Price float64        `json:"price"`
+}
+
+// ComicPriceType is the kind of a ComicPrice.
+type ComicPriceType string
+
+// Types of ComicPrices.
+const (
+	ComicPricePrint           ComicPriceType = "printPrice"
+	ComicPriceDigitalPurchase ComicPriceType = "digitalPurchasePrice"
+)
+
+// TextObject represents a descriptive text blurb for a Comic.
+type TextObject struct {
+	Type     TextObjectType `json:"type,omitempty"`
+	Language string         `json:"language,omitempty"`
+	Text     string         `json:"text,omitempty"`
+}
+
+// TextObjectType is the kind of a TextObject.
+type TextObjectType string
+
+// Types of TextObjects.
+const (
+	TextIssueSolicit TextObjectType = "issue_solicit_text"
+	TextIssuePreview TextObjectType = "issue_preview_text"
+)
+
+// DateOf returns the Comic's date of type t, and whether it has one.
+func (c Comic) DateOf(t ComicDateType) (Date, bool) {
+	for _, d := range c.Dates {
+		if d.Type == t {
+			return d.Date, true
+		}
+	}
+	return Date{}, false
+}
+
+// OnSaleDate returns the date the Comic went on sale, and whether it has one.
+func (c Comic) OnSaleDate() (Date, bool) {
+	return c.DateOf(ComicDateOnSale)
+}
+
+// FOCDate returns the Comic's final order cutoff date, and whether it has one.
+func (c Comic) FOCDate() (Date, bool) {
+	return c.DateOf(ComicDateFOC)
+}
+
+// PriceOf returns the Comic's price of type t, and whether it has one.
+func (c Comic) PriceOf(t ComicPriceType) (float64, bool) {
+	for _, p := range c.Prices {
+		if p.Type == t {
+			return p.Price, true
+		}
+	}
+	return 0, false
+}
+
+// PrintPrice returns the Comic's cover price, and whether it has one.
+func (c Comic) PrintPrice() (float64, bool) {
+	return c.PriceOf(ComicPricePrint)
+}
+
+// DigitalPurchasePrice returns the Comic's digital price, and whether it has
+// one.
+func (c Comic) DigitalPurchasePrice() (float64, bool) {
+	return c.PriceOf(ComicPriceDigitalPurchase)
+}
+
+// SolicitText returns the Comic's solicitation text in the language lang,
+// e.g. "en-us", or in any language if lang is empty. It returns "" if there is
+// no such text.
+func (c Comic) SolicitText(lang string) string {
+	for _, t := range c.TextObjects {
+		if t.Type == TextIssueSolicit && (lang == "" || strings.EqualFold(t.Language, lang)) {
+			return t.Text
+		}
+	}
+	return ""
+}
+
+// ComicSummary is the minimal representation of a Comic included in lists
+// and references from other entities. Use Fetch to get complete information.
+type ComicSummary struct {
+	ResourceURI *string `json:"resourceURI,omitempty"`
+	Name        *string `json:"name,omitempty"`
+}
+
+// ID returns the ID of the Comic, from its resource URI.
+func (s ComicSummary) ID() (int, error) {
+	return uriID(s.ResourceURI)
+}
+
+// Fetch issues a request to get complete information about the Comic.
+func (s ComicSummary) Fetch(ctx context.Context, cl Client) (*Comic, error) {
+	return fetchOne[Comic](ctx, cl, s.ResourceURI)
 }
 
 // ComicsList represents a list of Comics.
 type ComicsList struct {
 	ResourceList
-	Items []Comic `json:"items,omitempty"`
+	Items []ComicSummary `json:"items,omitempty"`
 }
 
 // List issues a request to get complete information about a list of Comics.
-func (l ComicsList) List(cl Client) (resp *ComicsResponse, err error) {
-	err = cl.fetch((*l.CollectionURI)[len(basePath):], nil, &resp)
-	return
+func (l ComicsList) List(cl Client) (*ComicsResponse, error) {
+	return l.ListContext(context.Background(), cl)
+}
+
+// ListContext is like List, with ctx controlling cancellation and deadlines.
+func (l ComicsList) ListContext(ctx context.Context, cl Client) (*ComicsResponse, error) {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Comic](ctx, cl, path, nil)
+}
+
+// All returns an iterator over the complete Comics in the list's collection
+// matching params, fetching pages as needed.
+func (l ComicsList) All(ctx context.Context, cl Client, params ComicsParams, opts PageOptions) iter.Seq2[Comic, error] {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return fail[Comic](err)
+	}
+	return listAll[Comic](ctx, cl, path, params, opts)
+}
+
+// Expand returns every complete Comic in the list's collection matching params.
+func (l ComicsList) Expand(ctx context.Context, cl Client, params ComicsParams) ([]Comic, error) {
+	return collect(l.All(ctx, cl, params, PageOptions{}))
 }
 
 /////
@@ -418,75 +1119,126 @@ func (l ComicsList) List(cl Client) (resp *ComicsResponse, err error) {
 
 // Creator begins to construct a request for information based on a Creator.
 func (c Client) Creator(id int) CreatorResource {
-	return CreatorResource{basePath: fmt.Sprintf("/creators/%d", id), client: c}
+	return CreatorResource{Resource[Creator]{basePath: fmt.Sprintf("/creators/%d", id), client: c}}
 }
 
 // CreatorResource provides methods to issue requests for a Creator.
 type CreatorResource struct {
-	basePath string
-	client   Client
+	Resource[Creator]
 }
 
 // Creators issues a request to search for Creators.
-func (c Client) Creators(params CreatorsParams) (resp *CreatorsResponse, err error) {
-	err = c.fetch("/creators", params, &resp)
-	return
+func (c Client) Creators(params CreatorsParams) (*CreatorsResponse, error) {
+	return c.CreatorsContext(context.Background(), params)
+}
+
+// CreatorsContext is like Creators, with ctx controlling cancellation and deadlines.
+func (c Client) CreatorsContext(ctx context.Context, params CreatorsParams) (*CreatorsResponse, error) {
+	return get[Creator](ctx, c, "/creators", params)
 }
 
-// Get issues a request to get a Creator.
-func (s CreatorResource) Get() (resp *CreatorsResponse, err error) {
-	err = s.client.fetch(s.basePath, nil, &resp)
-	return
+// AllCreators returns an iterator over all Creators matching params, fetching pages as needed.
+func (c Client) AllCreators(ctx context.Context, params CreatorsParams, opts PageOptions) iter.Seq2[Creator, error] {
+	return listAll[Creator](ctx, c, "/creators", params, opts)
 }
 
 // Comics issues a request to search for Comics associated with a Creator.
-func (s CreatorResource) Comics(params ComicsParams) (resp *ComicsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/comics", params, &resp)
-	return
+func (s CreatorResource) Comics(params ComicsParams) (*ComicsResponse, error) {
+	return s.ComicsContext(context.Background(), params)
+}
+
+// ComicsContext is like Comics, with ctx controlling cancellation and deadlines.
+func (s CreatorResource) ComicsContext(ctx context.Context, params ComicsParams) (*ComicsResponse, error) {
+	return get[Comic](ctx, s.client, s.basePath+"/comics", params)
+}
+
+// AllComics returns an iterator over all Comics associated with a Creator, fetching pages as needed.
+func (s CreatorResource) AllComics(ctx context.Context, params ComicsParams, opts PageOptions) iter.Seq2[Comic, error] {
+	return listAll[Comic](ctx, s.client, s.basePath+"/comics", params, opts)
 }
 
 // Events issues a request to search for Events associated with a Creator.
-func (s CreatorResource) Events(params EventsParams) (resp *EventsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/events", params, &resp)
-	return
+func (s CreatorResource) Events(params EventsParams) (*EventsResponse, error) {
+	return s.EventsContext(context.Background(), params)
+}
+
+// EventsContext is like Events, with ctx controlling cancellation and deadlines.
+func (s CreatorResource) EventsContext(ctx context.Context, params EventsParams) (*EventsResponse, error) {
+	return get[Event](ctx, s.client, s.basePath+"/events", params)
+}
+
+// AllEvents returns an iterator over all Events associated with a Creator, fetching pages as needed.
+func (s CreatorResource) AllEvents(ctx context.Context, params EventsParams, opts PageOptions) iter.Seq2[Event, error] {
+	return listAll[Event](ctx, s.client, s.basePath+"/events", params, opts)
 }
 
 // Series issues a request to search for Series associated with a Creator.
-func (s CreatorResource) Series(params SeriesParams) (resp *SeriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/series", params, &resp)
-	return
+func (s CreatorResource) Series(params SeriesParams) (*SeriesResponse, error) {
+	return s.SeriesContext(context.Background(), params)
+}
+
+// SeriesContext is like Series, with ctx controlling cancellation and deadlines.
+func (s CreatorResource) SeriesContext(ctx context.Context, params SeriesParams) (*SeriesResponse, error) {
+	return get[Series](ctx, s.client, s.basePath+"/series", params)
+}
+
+// AllSeries returns an iterator over all Series associated with a Creator, fetching pages as needed.
+func (s CreatorResource) AllSeries(ctx context.Context, params SeriesParams, opts PageOptions) iter.Seq2[Series, error] {
+	return listAll[Series](ctx, s.client, s.basePath+"/series", params, opts)
 }
 
 // Stories issues a request to search for Stories associated with a Creator.
-func (s CreatorResource) Stories(params StoriesParams) (resp *StoriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/stories", params, &resp)
-	return
+func (s CreatorResource) Stories(params StoriesParams) (*StoriesResponse, error) {
+	return s.StoriesContext(context.Background(), params)
+}
+
+// StoriesContext is like Stories, with ctx controlling cancellation and deadlines.
+func (s CreatorResource) StoriesContext(ctx context.Context, params StoriesParams) (*StoriesResponse, error) {
+	return get[Story](ctx, s.client, s.basePath+"/stories", params)
+}
+
+// AllStories returns an iterator over all Stories associated with a Creator, fetching pages as needed.
+func (s CreatorResource) AllStories(ctx context.Context, params StoriesParams, opts PageOptions) iter.Seq2[Story, error] {
+	return listAll[Story](ctx, s.client, s.basePath+"/stories", params, opts)
 }
 
 // CreatorsParams represents parameters to search for Creators.
 type CreatorsParams struct {
 	CommonParams
-	FirstName            string `url:"firstName,omitempty"`
-	MiddleName           string `url:"middleName,omitempty"`
-	LastName             string `url:"lastName,omitempty"`
-	Suffix               string `url:"suffix,omitempty"`
-	NameStartsWith       string `url:"nameStartsWith,omitempty"`
-	FirstNameStartsWith  string `url:"firstNameStartsWith,omitempty"`
-	MiddleNameStartsWith string `url:"middleNameStartsWith,omitempty"`
-	LastNameStartsWith   string `url:"lastNameStartsWith,omitempty"`
-	Comics               []int  `url:"comics,omitempty,comma"`
-	Events               []int  `url:"events,omitempty,comma"`
-	Stories              []int  `url:"stories,omitempty,comma"`
-}
+	OrderBy              []CreatorOrder `url:"orderBy,omitempty,comma"`
+	FirstName            string         `url:"firstName,omitempty"`
+	MiddleName           string         `url:"middleName,omitempty"`
+	LastName             string         `url:"lastName,omitempty"`
+	Suffix               string         `url:"suffix,omitempty"`
+	NameStartsWith       string         `url:"nameStartsWith,omitempty"`
+	FirstNameStartsWith  string         `url:"firstNameStartsWith,omitempty"`
+	MiddleNameStartsWith string         `url:"middleNameStartsWith,omitempty"`
+	LastNameStartsWith   string         `url:"lastNameStartsWith,omitempty"`
+	Comics               []int          `url:"comics,omitempty,comma"`
+	Events               []int          `url:"events,omitempty,comma"`
+	Stories              []int          `url:"stories,omitempty,comma"`
+}
+
+// CreatorOrder is a field by which Creators can be ordered.
+// Orderings ending in Desc sort in descending order.
+type CreatorOrder string
+
+// Orderings for Creators.
+const (
+	CreatorOrderLastName       CreatorOrder = "lastName"
+	CreatorOrderLastNameDesc   CreatorOrder = "-lastName"
+	CreatorOrderFirstName      CreatorOrder = "firstName"
+	CreatorOrderFirstNameDesc  CreatorOrder = "-firstName"
+	CreatorOrderMiddleName     CreatorOrder = "middleName"
+	CreatorOrderMiddleNameDesc CreatorOrder = "-middleName"
+	CreatorOrderSuffix         CreatorOrder = "suffix"
+	CreatorOrderSuffixDesc     CreatorOrder = "-suffix"
+	CreatorOrderModified       CreatorOrder = "modified"
+	CreatorOrderModifiedDesc   CreatorOrder = "-modified"
+)
 
 // CreatorsResponse represents responses to methods that return Creators.
-type CreatorsResponse struct {
-	CommonResponse
-	Data struct {
-		CommonList
-		Results []Creator `json:"results,omitempty"`
-	} `json:"data,omitempty"`
-}
+type CreatorsResponse = Response[Creator]
 
 // Creator represents a single Creator.
 type Creator struct {
@@ -505,24 +1257,78 @@ type Creator struct {
 	Stories     *StoriesList `json:"stories,omitempty"`
 	Comics      *ComicsList  `json:"comics,omitempty"`
 	Events      *EventsList  `json:"events,omitempty"`
+
+	// Extra holds the fields returned by the API that are not otherwise
+	// decoded, by name.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Get issues a request to get complete information about a Creator.
-func (c Creator) Get(cl Client) (resp *CreatorsResponse, err error) {
-	err = cl.fetch((*c.ResourceURI)[len(basePath):], nil, &resp)
-	return
+func (c Creator) Get(cl Client) (*CreatorsResponse, error) {
+	return c.GetContext(context.Background(), cl)
+}
+
+// GetContext is like Get, with ctx controlling cancellation and deadlines.
+func (c Creator) GetContext(ctx context.Context, cl Client) (*CreatorsResponse, error) {
+	path, err := cl.resourcePath(c.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Creator](ctx, cl, path, nil)
+}
+
+// CreatorSummary is the minimal representation of a Creator included in lists
+// and references from other entities. Use Fetch to get complete information.
+type CreatorSummary struct {
+	ResourceURI *string `json:"resourceURI,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	// Role is the role of the creator in the parent entity, e.g. "writer".
+	Role *string `json:"role,omitempty"`
+}
+
+// ID returns the ID of the Creator, from its resource URI.
+func (s CreatorSummary) ID() (int, error) {
+	return uriID(s.ResourceURI)
+}
+
+// Fetch issues a request to get complete information about the Creator.
+func (s CreatorSummary) Fetch(ctx context.Context, cl Client) (*Creator, error) {
+	return fetchOne[Creator](ctx, cl, s.ResourceURI)
 }
 
 // CreatorsList represents a list of Creators.
 type CreatorsList struct {
 	ResourceList
-	Items []Creator
+	Items []CreatorSummary `json:"items,omitempty"`
 }
 
 // List issues a request to get complete information about a list of Creators.
-func (l CreatorsList) List(cl Client) (resp *CreatorsResponse, err error) {
-	err = cl.fetch((*l.CollectionURI)[len(basePath):], nil, &resp)
-	return
+func (l CreatorsList) List(cl Client) (*CreatorsResponse, error) {
+	return l.ListContext(context.Background(), cl)
+}
+
+// ListContext is like List, with ctx controlling cancellation and deadlines.
+func (l CreatorsList) ListContext(ctx context.Context, cl Client) (*CreatorsResponse, error) {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Creator](ctx, cl, path, nil)
+}
+
+// All returns an iterator over the complete Creators in the list's collection
+// matching params, fetching pages as needed.
+func (l CreatorsList) All(ctx context.Context, cl Client, params CreatorsParams, opts PageOptions) iter.Seq2[Creator, error] {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return fail[Creator](err)
+	}
+	return listAll[Creator](ctx, cl, path, params, opts)
+}
+
+// Expand returns every complete Creator in the list's collection matching params.
+func (l CreatorsList) Expand(ctx context.Context, cl Client, params CreatorsParams) ([]Creator, error) {
+	return collect(l.All(ctx, cl, params, PageOptions{}))
 }
 
 /////
@@ -531,76 +1337,132 @@ func (l CreatorsList) List(cl Client) (resp *CreatorsResponse, err error) {
 
 // Event begins to construct a request for information based on an Event.
 func (c Client) Event(id int) EventResource {
-	return EventResource{basePath: fmt.Sprintf("events/%d", id), client: c}
+	return EventResource{Resource[Event]{basePath: fmt.Sprintf("/events/%d", id), client: c}}
 }
 
 // EventResource provides methods to issue requests for an Event.
 type EventResource struct {
-	basePath string
-	client   Client
+	Resource[Event]
 }
 
 // Events issues a request to search for Events.
-func (c Client) Events(params EventsParams) (resp *EventsResponse, err error) {
-	err = c.fetch("/events", params, &resp)
-	return
+func (c Client) Events(params EventsParams) (*EventsResponse, error) {
+	return c.EventsContext(context.Background(), params)
 }
 
-// Get issues a request to get a Event.
-func (s EventResource) Get() (resp *EventsResponse, err error) {
-	err = s.client.fetch(s.basePath, nil, &resp)
-	return
+// EventsContext is like Events, with ctx controlling cancellation and deadlines.
+func (c Client) EventsContext(ctx context.Context, params EventsParams) (*EventsResponse, error) {
+	return get[Event](ctx, c, "/events", params)
 }
 
-// Characters issues a request to search for Characters associated with a Character.
-func (s EventResource) Characters(params CharactersParams) (resp *CharactersResponse, err error) {
-	err = s.client.fetch(s.basePath+"/characters", params, &resp)
-	return
+// AllEvents returns an iterator over all Events matching params, fetching pages as needed.
+func (c Client) AllEvents(ctx context.Context, params EventsParams, opts PageOptions) iter.Seq2[Event, error] {
+	return listAll[Event](ctx, c, "/events", params, opts)
 }
 
-// Comics issues a request to search for Comics associated with a Character.
-func (s EventResource) Comics(params ComicsParams) (resp *ComicsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/comics", params, &resp)
-	return
+// Characters issues a request to search for Characters associated with an Event.
+func (s EventResource) Characters(params CharactersParams) (*CharactersResponse, error) {
+	return s.CharactersContext(context.Background(), params)
 }
 
-// Creators issues a request to search for Creators associated with a Character.
-func (s EventResource) Creators(params CreatorsParams) (resp *CreatorsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/creators", params, &resp)
-	return
+// CharactersContext is like Characters, with ctx controlling cancellation and deadlines.
+func (s EventResource) CharactersContext(ctx context.Context, params CharactersParams) (*CharactersResponse, error) {
+	return get[Character](ctx, s.client, s.basePath+"/characters", params)
 }
 
-// Series issues a request to search for Series' associated with a Character.
-func (s EventResource) Series(params SeriesParams) (resp *SeriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/series", params, &resp)
-	return
+// AllCharacters returns an iterator over all Characters associated with an Event, fetching pages as needed.
+func (s EventResource) AllCharacters(ctx context.Context, params CharactersParams, opts PageOptions) iter.Seq2[Character, error] {
+	return listAll[Character](ctx, s.client, s.basePath+"/characters", params, opts)
 }
 
-// Stories issues a request to search for Stories associated with a Character.
-func (s EventResource) Stories(params StoriesParams) (resp *StoriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/stories", params, &resp)
-	return
+// Comics issues a request to search for Comics associated with an Event.
+func (s EventResource) Comics(params ComicsParams) (*ComicsResponse, error) {
+	return s.ComicsContext(context.Background(), params)
+}
+
+// ComicsContext is like Comics, with ctx controlling cancellation and deadlines.
+func (s EventResource) ComicsContext(ctx context.Context, params ComicsParams) (*ComicsResponse, error) {
+	return get[Comic](ctx, s.client, s.basePath+"/comics", params)
+}
+
+// AllComics returns an iterator over all Comics associated with an Event, fetching pages as needed.
+func (s EventResource) AllComics(ctx context.Context, params ComicsParams, opts PageOptions) iter.Seq2[Comic, error] {
+	return listAll[Comic](ctx, s.client, s.basePath+"/comics", params, opts)
+}
+
+// Creators issues a request to search for Creators associated with an Event.
+func (s EventResource) Creators(params CreatorsParams) (*CreatorsResponse, error) {
+	return s.CreatorsContext(context.Background(), params)
+}
+
+// CreatorsContext is like Creators, with ctx controlling cancellation and deadlines.
+func (s EventResource) CreatorsContext(ctx context.Context, params CreatorsParams) (*CreatorsResponse, error) {
+	return get[Creator](ctx, s.client, s.basePath+"/creators", params)
+}
+
+// AllCreators returns an iterator over all Creators associated with an Event, fetching pages as needed.
+func (s EventResource) AllCreators(ctx context.Context, params CreatorsParams, opts PageOptions) iter.Seq2[Creator, error] {
+	return listAll[Creator](ctx, s.client, s.basePath+"/creators", params, opts)
+}
+
+// Series issues a request to search for Series associated with an Event.
+func (s EventResource) Series(params SeriesParams) (*SeriesResponse, error) {
+	return s.SeriesContext(context.Background(), params)
+}
+
+// SeriesContext is like Series, with ctx controlling cancellation and deadlines.
+func (s EventResource) SeriesContext(ctx context.Context, params SeriesParams) (*SeriesResponse, error) {
+	return get[Series](ctx, s.client, s.basePath+"/series", params)
+}
+
+// AllSeries returns an iterator over all Series associated with an Event, fetching pages as needed.
+func (s EventResource) AllSeries(ctx context.Context, params SeriesParams, opts PageOptions) iter.Seq2[Series, error] {
+	return listAll[Series](ctx, s.client, s.basePath+"/series", params, opts)
+}
+
+// Stories issues a request to search for Stories associated with an Event.
+func (s EventResource) Stories(params StoriesParams) (*StoriesResponse, error) {
+	return s.StoriesContext(context.Background(), params)
+}
+
+// StoriesContext is like Stories, with ctx controlling cancellation and deadlines.
+func (s EventResource) StoriesContext(ctx context.Context, params StoriesParams) (*StoriesResponse, error) {
+	return get[Story](ctx, s.client, s.basePath+"/stories", params)
+}
+
+// AllStories returns an iterator over all Stories associated with an Event, fetching pages as needed.
+func (s EventResource) AllStories(ctx context.Context, params StoriesParams, opts PageOptions) iter.Seq2[Story, error] {
+	return listAll[Story](ctx, s.client, s.basePath+"/stories", params, opts)
 }
 
 // EventsParams represents parameters to search for Events.
 type EventsParams struct {
 	CommonParams
-	Name           string `url:"name,omitempty"`
-	NameStartsWith string `url:"nameStartsWith,omitempty"`
-	Creators       []int  `url:"creators,omitempty,comma"`
-	Characters     []int  `url:"characters,omitempty,comma"`
-	Comics         []int  `url:"comics,omitempty,comma"`
-	Stories        []int  `url:"stories,omitempty,comma"`
+	OrderBy        []EventOrder `url:"orderBy,omitempty,comma"`
+	Name           string       `url:"name,omitempty"`
+	NameStartsWith string       `url:"nameStartsWith,omitempty"`
+	Creators       []int        `url:"creators,omitempty,comma"`
+	Characters     []int        `url:"characters,omitempty,comma"`
+	Comics         []int        `url:"comics,omitempty,comma"`
+	Stories        []int        `url:"stories,omitempty,comma"`
 }
 
+// EventOrder is a field by which Events can be ordered.
+// Orderings ending in Desc sort in descending order.
+type EventOrder string
+
+// Orderings for Events.
+const (
+	EventOrderName          EventOrder = "name"
+	EventOrderNameDesc      EventOrder = "-name"
+	EventOrderStartDate     EventOrder = "startDate"
+	EventOrderStartDateDesc EventOrder = "-startDate"
+	EventOrderModified      EventOrder = "modified"
+	EventOrderModifiedDesc  EventOrder = "-modified"
+)
+
 // EventsResponse represents responses to methods that return Events.
-type EventsResponse struct {
-	CommonResponse
-	Data struct {
-		CommonList
-		Results []Event `json:"results,omitempty"`
-	} `json:"data,omitempty"`
-}
+type EventsResponse = Response[Event]
 
 // Event represents a single Event.
 type Event struct {
@@ -618,26 +1480,78 @@ type Event struct {
 	Series      *SeriesList     `json:"series,omitempty"`
 	Characters  *CharactersList `json:"characters,omitempty"`
 	Creators    *CreatorsList   `json:"creators,omitempty"`
-	Next        *Event          `json:"next,omitempty"`
-	Previous    *Event          `json:"next,omitempty"`
+	Next        *EventSummary   `json:"next,omitempty"`
+	Previous    *EventSummary   `json:"previous,omitempty"`
+
+	// Extra holds the fields returned by the API that are not otherwise
+	// decoded, by name.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Get issues a request to get complete information about an Event.
-func (e Event) Get(cl Client) (resp *EventsResponse, err error) {
-	err = cl.fetch((*e.ResourceURI)[len(basePath):], nil, &resp)
-	return
+func (e Event) Get(cl Client) (*EventsResponse, error) {
+	return e.GetContext(context.Background(), cl)
+}
+
+// GetContext is like Get, with ctx controlling cancellation and deadlines.
+func (e Event) GetContext(ctx context.Context, cl Client) (*EventsResponse, error) {
+	path, err := cl.resourcePath(e.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Event](ctx, cl, path, nil)
+}
+
+// EventSummary is the minimal representation of an Event included in lists
+// and references from other entities. Use Fetch to get complete information.
+type EventSummary struct {
+	ResourceURI *string `json:"resourceURI,omitempty"`
+	Name        *string `json:"name,omitempty"`
+}
+
+// ID returns the ID of the Event, from its resource URI.
+func (s EventSummary) ID() (int, error) {
+	return uriID(s.ResourceURI)
+}
+
+// Fetch issues a request to get complete information about the Event.
+func (s EventSummary) Fetch(ctx context.Context, cl Client) (*Event, error) {
+	return fetchOne[Event](ctx, cl, s.ResourceURI)
 }
 
 // EventsList represents a list of Events.
 type EventsList struct {
 	ResourceList
-	Items []Event `json:"items,omitempty"`
+	Items []EventSummary `json:"items,omitempty"`
 }
 
 // List issues a request to get complete information about a list of Events.
-func (l EventsList) List(cl Client) (resp *EventsResponse, err error) {
-	err = cl.fetch((*l.CollectionURI)[len(basePath):], nil, &resp)
-	return
+func (l EventsList) List(cl Client) (*EventsResponse, error) {
+	return l.ListContext(context.Background(), cl)
+}
+
+// ListContext is like List, with ctx controlling cancellation and deadlines.
+func (l EventsList) ListContext(ctx context.Context, cl Client) (*EventsResponse, error) {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Event](ctx, cl, path, nil)
+}
+
+// All returns an iterator over the complete Events in the list's collection
+// matching params, fetching pages as needed.
+func (l EventsList) All(ctx context.Context, cl Client, params EventsParams, opts PageOptions) iter.Seq2[Event, error] {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return fail[Event](err)
+	}
+	return listAll[Event](ctx, cl, path, params, opts)
+}
+
+// Expand returns every complete Event in the list's collection matching params.
+func (l EventsList) Expand(ctx context.Context, cl Client, params EventsParams) ([]Event, error) {
+	return collect(l.All(ctx, cl, params, PageOptions{}))
 }
 
 /////
@@ -647,79 +1561,146 @@ func (l EventsList) List(cl Client) (resp *EventsResponse, err error) {
 
 // SingleSeries begins to construct a request for information based on a Series.
 func (c Client) SingleSeries(id int) SeriesResource {
-	return SeriesResource{basePath: fmt.Sprintf("/series/%d", id), client: c}
+	return SeriesResource{Resource[Series]{basePath: fmt.Sprintf("/series/%d", id), client: c}}
 }
 
 // SeriesResource provides methods to issue requests for a Series.
 type SeriesResource struct {
-	basePath string
-	client   Client
+	Resource[Series]
 }
 
 // Series issues a request to search for Series'.
-func (c Client) Series(params SeriesParams) (resp *SeriesResponse, err error) {
-	err = c.fetch("/series", params, &resp)
-	return
+func (c Client) Series(params SeriesParams) (*SeriesResponse, error) {
+	return c.SeriesContext(context.Background(), params)
 }
 
-// Get issues a request to get a single Series.
-func (s SeriesResource) Get() (resp *SeriesResponse, err error) {
-	err = s.client.fetch(s.basePath, nil, &resp)
-	return
+// SeriesContext is like Series, with ctx controlling cancellation and deadlines.
+func (c Client) SeriesContext(ctx context.Context, params SeriesParams) (*SeriesResponse, error) {
+	return get[Series](ctx, c, "/series", params)
+}
+
+// AllSeries returns an iterator over all Series matching params, fetching pages as needed.
+func (c Client) AllSeries(ctx context.Context, params SeriesParams, opts PageOptions) iter.Seq2[Series, error] {
+	return listAll[Series](ctx, c, "/series", params, opts)
 }
 
 // Characters issues a request to search for Characters associated with a Series.
-func (s SeriesResource) Characters(params CharactersParams) (resp *CharactersResponse, err error) {
-	err = s.client.fetch(s.basePath+"/characters", params, resp)
-	return
+func (s SeriesResource) Characters(params CharactersParams) (*CharactersResponse, error) {
+	return s.CharactersContext(context.Background(), params)
+}
+
+// CharactersContext is like Characters, with ctx controlling cancellation and deadlines.
+func (s SeriesResource) CharactersContext(ctx context.Context, params CharactersParams) (*CharactersResponse, error) {
+	return get[Character](ctx, s.client, s.basePath+"/characters", params)
+}
+
+// AllCharacters returns an iterator over all Characters associated with a Series, fetching pages as needed.
+func (s SeriesResource) AllCharacters(ctx context.Context, params CharactersParams, opts PageOptions) iter.Seq2[Character, error] {
+	return listAll[Character](ctx, s.client, s.basePath+"/characters", params, opts)
 }
 
 // Comics issues a request to search for Comics associated with a Series.
-func (s SeriesResource) Comics(params ComicsParams) (resp *ComicsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/comics", params, &resp)
-	return
+func (s SeriesResource) Comics(params ComicsParams) (*ComicsResponse, error) {
+	return s.ComicsContext(context.Background(), params)
+}
+
+// ComicsContext is like Comics, with ctx controlling cancellation and deadlines.
+func (s SeriesResource) ComicsContext(ctx context.Context, params ComicsParams) (*ComicsResponse, error) {
+	return get[Comic](ctx, s.client, s.basePath+"/comics", params)
+}
+
+// AllComics returns an iterator over all Comics associated with a Series, fetching pages as needed.
+func (s SeriesResource) AllComics(ctx context.Context, params ComicsParams, opts PageOptions) iter.Seq2[Comic, error] {
+	return listAll[Comic](ctx, s.client, s.basePath+"/comics", params, opts)
 }
 
 // Creators issues a request to search for Creators associated with a Series.
-func (s SeriesResource) Creators(params CreatorsParams) (resp *CreatorsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/creators", params, &resp)
-	return
+func (s SeriesResource) Creators(params CreatorsParams) (*CreatorsResponse, error) {
+	return s.CreatorsContext(context.Background(), params)
+}
+
+// CreatorsContext is like Creators, with ctx controlling cancellation and deadlines.
+func (s SeriesResource) CreatorsContext(ctx context.Context, params CreatorsParams) (*CreatorsResponse, error) {
+	return get[Creator](ctx, s.client, s.basePath+"/creators", params)
+}
+
+// AllCreators returns an iterator over all Creators associated with a Series, fetching pages as needed.
+func (s SeriesResource) AllCreators(ctx context.Context, params CreatorsParams, opts PageOptions) iter.Seq2[Creator, error] {
+	return listAll[Creator](ctx, s.client, s.basePath+"/creators", params, opts)
 }
 
 // Events issues a request to search for Events associated with a Series.
-func (s SeriesResource) Events(params EventsParams) (resp *EventsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/events", params, &resp)
-	return
+func (s SeriesResource) Events(params EventsParams) (*EventsResponse, error) {
+	return s.EventsContext(context.Background(), params)
+}
+
+// EventsContext is like Events, with ctx controlling cancellation and deadlines.
+func (s SeriesResource) EventsContext(ctx context.Context, params EventsParams) (*EventsResponse, error) {
+	return get[Event](ctx, s.client, s.basePath+"/events", params)
+}
+
+// AllEvents returns an iterator over all Events associated with a Series, fetching pages as needed.
+func (s SeriesResource) AllEvents(ctx context.Context, params EventsParams, opts PageOptions) iter.Seq2[Event, error] {
+	return listAll[Event](ctx, s.client, s.basePath+"/events", params, opts)
 }
 
 // Stories issues a request to search for Stories associated with a Series.
-func (s SeriesResource) Stories(params StoriesParams) (resp *StoriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/stories", params, &resp)
-	return
+func (s SeriesResource) Stories(params StoriesParams) (*StoriesResponse, error) {
+	return s.StoriesContext(context.Background(), params)
+}
+
+// StoriesContext is like Stories, with ctx controlling cancellation and deadlines.
+func (s SeriesResource) StoriesContext(ctx context.Context, params StoriesParams) (*StoriesResponse, error) {
+	return get[Story](ctx, s.client, s.basePath+"/stories", params)
+}
+
+// AllStories returns an iterator over all Stories associated with a Series, fetching pages as needed.
+func (s SeriesResource) AllStories(ctx context.Context, params StoriesParams, opts PageOptions) iter.Seq2[Story, error] {
+	return listAll[Story](ctx, s.client, s.basePath+"/stories", params, opts)
 }
 
 // SeriesParams represents parameters to search for Series'.
 type SeriesParams struct {
 	CommonParams
-	Events          string `url:"events,omitempty"`
-	Title           string `url:"title,omitempty"`
-	TitleStartsWith string `url:"titleStartsWith,omitempty"`
-	StartYear       int    `url:"startYear,omitempty"`
-	SeriesType      string `url:"seriesType,omitempty"`
-	Contains        string `url:"contains,omitempty"`
-	Comics          []int  `url:"comics,omitempty,comma"`
-	Creators        []int  `url:"creators,omitempty,comma"`
-	Characters      []int  `url:"characters,omitempty,comma"`
-}
+	OrderBy         []SeriesOrder `url:"orderBy,omitempty,comma"`
+	Events          string        `url:"events,omitempty"`
+	Title           string        `url:"title,omitempty"`
+	TitleStartsWith string        `url:"titleStartsWith,omitempty"`
+	StartYear       int           `url:"startYear,omitempty"`
+	SeriesType      SeriesType    `url:"seriesType,omitempty"`
+	Contains        []Format      `url:"contains,omitempty,comma"`
+	Comics          []int         `url:"comics,omitempty,comma"`
+	Creators        []int         `url:"creators,omitempty,comma"`
+	Characters      []int         `url:"characters,omitempty,comma"`
+}
+
+// SeriesType is the publication type of a Series.
+type SeriesType string
+
+// Types of Series.
+const (
+	SeriesTypeCollection SeriesType = "collection"
+	SeriesTypeOneShot    SeriesType = "one shot"
+	SeriesTypeLimited    SeriesType = "limited"
+	SeriesTypeOngoing    SeriesType = "ongoing"
+)
+
+// SeriesOrder is a field by which Series can be ordered.
+// Orderings ending in Desc sort in descending order.
+type SeriesOrder string
+
+// Orderings for Series.
+const (
+	SeriesOrderTitle         SeriesOrder = "title"
+	SeriesOrderTitleDesc     SeriesOrder = "-title"
+	SeriesOrderModified      SeriesOrder = "modified"
+	SeriesOrderModifiedDesc  SeriesOrder = "-modified"
+	SeriesOrderStartYear     SeriesOrder = "startYear"
+	SeriesOrderStartYearDesc SeriesOrder = "-startYear"
+)
 
 // SeriesResponse represents responses to methods that return Series'.
-type SeriesResponse struct {
-	CommonResponse
-	Data struct {
-		CommonList
-		Results []Series `json:"results,omitempty"`
-	} `json:"data,omitempty"`
-}
+type SeriesResponse = Response[Series]
 
 // Series represents a single Series.
 type Series struct {
@@ -729,6 +1710,7 @@ type Series struct {
 	Title       *string         `json:"title,omitempty"`
 	Description *string         `json:"description,omitempty"`
 	URLs        []URL           `json:"urls,omitempty"`
+	Type        *SeriesType     `json:"type,omitempty"`
 	StartYear   *int            `json:"startYear,omitempty"`
 	EndYear     *int            `json:"endYear,omitempty"`
 	Rating      *string         `json:"rating,omitempty"`
@@ -739,26 +1721,78 @@ type Series struct {
 	Events      *EventsList     `json:"events,omitempty"`
 	Characters  *CharactersList `json:"characters,omitempty"`
 	Creators    *CreatorsList   `json:"creators,omitempty"`
-	Next        *Series         `json:"next,omitempty"`
-	Previous    *Series         `json:"next,omitempty"`
+	Next        *SeriesSummary  `json:"next,omitempty"`
+	Previous    *SeriesSummary  `json:"previous,omitempty"`
+
+	// Extra holds the fields returned by the API that are not otherwise
+	// decoded, by name.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Get issues a request to get complete information about a Series.
-func (s Series) Get(cl Client) (resp *SeriesResponse, err error) {
-	err = cl.fetch((*s.ResourceURI)[len(basePath):], nil, &resp)
-	return
+func (s Series) Get(cl Client) (*SeriesResponse, error) {
+	return s.GetContext(context.Background(), cl)
+}
+
+// GetContext is like Get, with ctx controlling cancellation and deadlines.
+func (s Series) GetContext(ctx context.Context, cl Client) (*SeriesResponse, error) {
+	path, err := cl.resourcePath(s.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Series](ctx, cl, path, nil)
+}
+
+// SeriesSummary is the minimal representation of a Series included in lists
+// and references from other entities. Use Fetch to get complete information.
+type SeriesSummary struct {
+	ResourceURI *string `json:"resourceURI,omitempty"`
+	Name        *string `json:"name,omitempty"`
+}
+
+// ID returns the ID of the Series, from its resource URI.
+func (s SeriesSummary) ID() (int, error) {
+	return uriID(s.ResourceURI)
+}
+
+// Fetch issues a request to get complete information about the Series.
+func (s SeriesSummary) Fetch(ctx context.Context, cl Client) (*Series, error) {
+	return fetchOne[Series](ctx, cl, s.ResourceURI)
 }
 
 // SeriesList represents a list of Series'.
 type SeriesList struct {
 	ResourceList
-	Items []Series
+	Items []SeriesSummary `json:"items,omitempty"`
 }
 
 // List issues a request to get complete information about a list of Series'.
-func (l SeriesList) List(cl Client) (resp *SeriesResponse, err error) {
-	err = cl.fetch((*l.CollectionURI)[len(basePath):], nil, &resp)
-	return
+func (l SeriesList) List(cl Client) (*SeriesResponse, error) {
+	return l.ListContext(context.Background(), cl)
+}
+
+// ListContext is like List, with ctx controlling cancellation and deadlines.
+func (l SeriesList) ListContext(ctx context.Context, cl Client) (*SeriesResponse, error) {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Series](ctx, cl, path, nil)
+}
+
+// All returns an iterator over the complete Series in the list's collection
+// matching params, fetching pages as needed.
+func (l SeriesList) All(ctx context.Context, cl Client, params SeriesParams, opts PageOptions) iter.Seq2[Series, error] {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return fail[Series](err)
+	}
+	return listAll[Series](ctx, cl, path, params, opts)
+}
+
+// Expand returns every complete Series in the list's collection matching params.
+func (l SeriesList) Expand(ctx context.Context, cl Client, params SeriesParams) ([]Series, error) {
+	return collect(l.All(ctx, cl, params, PageOptions{}))
 }
 
 /////
@@ -767,74 +1801,128 @@ func (l SeriesList) List(cl Client) (resp *SeriesResponse, err error) {
 
 // Story begins to construct a request for information based on a Story.
 func (c Client) Story(id int) StoryResource {
-	return StoryResource{basePath: fmt.Sprintf("stories/%d", id), client: c}
+	return StoryResource{Resource[Story]{basePath: fmt.Sprintf("/stories/%d", id), client: c}}
 }
 
 // StoryResource provides methods to issue requests for a Story.
 type StoryResource struct {
-	basePath string
-	client   Client
+	Resource[Story]
 }
 
 // Stories issues a request to search for Stories.
-func (c Client) Stories(params StoriesParams) (resp *StoriesResponse, err error) {
-	err = c.fetch("/stories", params, &resp)
-	return
+func (c Client) Stories(params StoriesParams) (*StoriesResponse, error) {
+	return c.StoriesContext(context.Background(), params)
+}
+
+// StoriesContext is like Stories, with ctx controlling cancellation and deadlines.
+func (c Client) StoriesContext(ctx context.Context, params StoriesParams) (*StoriesResponse, error) {
+	return get[Story](ctx, c, "/stories", params)
 }
 
-// Get issues a request to get a Story.
-func (s StoryResource) Get() (resp *StoriesResponse, err error) {
-	err = s.client.fetch(s.basePath, nil, &resp)
-	return
+// AllStories returns an iterator over all Stories matching params, fetching pages as needed.
+func (c Client) AllStories(ctx context.Context, params StoriesParams, opts PageOptions) iter.Seq2[Story, error] {
+	return listAll[Story](ctx, c, "/stories", params, opts)
 }
 
 // Characters issues a request to search for Characters associated with a Story.
-func (s StoryResource) Characters(params CharactersParams) (resp *CharactersResponse, err error) {
-	err = s.client.fetch(s.basePath+"/characters", params, &resp)
-	return
+func (s StoryResource) Characters(params CharactersParams) (*CharactersResponse, error) {
+	return s.CharactersContext(context.Background(), params)
+}
+
+// CharactersContext is like Characters, with ctx controlling cancellation and deadlines.
+func (s StoryResource) CharactersContext(ctx context.Context, params CharactersParams) (*CharactersResponse, error) {
+	return get[Character](ctx, s.client, s.basePath+"/characters", params)
+}
+
+// AllCharacters returns an iterator over all Characters associated with a Story, fetching pages as needed.
+func (s StoryResource) AllCharacters(ctx context.Context, params CharactersParams, opts PageOptions) iter.Seq2[Character, error] {
+	return listAll[Character](ctx, s.client, s.basePath+"/characters", params, opts)
 }
 
 // Comics issues a request to search for Comics associated with a Story.
-func (s StoryResource) Comics(params ComicsParams) (resp *ComicsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/comics", params, &resp)
-	return
+func (s StoryResource) Comics(params ComicsParams) (*ComicsResponse, error) {
+	return s.ComicsContext(context.Background(), params)
+}
+
+// ComicsContext is like Comics, with ctx controlling cancellation and deadlines.
+func (s StoryResource) ComicsContext(ctx context.Context, params ComicsParams) (*ComicsResponse, error) {
+	return get[Comic](ctx, s.client, s.basePath+"/comics", params)
+}
+
+// AllComics returns an iterator over all Comics associated with a Story, fetching pages as needed.
+func (s StoryResource) AllComics(ctx context.Context, params ComicsParams, opts PageOptions) iter.Seq2[Comic, error] {
+	return listAll[Comic](ctx, s.client, s.basePath+"/comics", params, opts)
 }
 
 // Creators issues a request to search for Creators associated with a Story.
-func (s StoryResource) Creators(params CreatorsParams) (resp *CreatorsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/creators", params, &resp)
-	return
+func (s StoryResource) Creators(params CreatorsParams) (*CreatorsResponse, error) {
+	return s.CreatorsContext(context.Background(), params)
+}
+
+// CreatorsContext is like Creators, with ctx controlling cancellation and deadlines.
+func (s StoryResource) CreatorsContext(ctx context.Context, params CreatorsParams) (*CreatorsResponse, error) {
+	return get[Creator](ctx, s.client, s.basePath+"/creators", params)
+}
+
+// AllCreators returns an iterator over all Creators associated with a Story, fetching pages as needed.
+func (s StoryResource) AllCreators(ctx context.Context, params CreatorsParams, opts PageOptions) iter.Seq2[Creator, error] {
+	return listAll[Creator](ctx, s.client, s.basePath+"/creators", params, opts)
 }
 
 // Events issues a request to search for Events associated with a Story.
-func (s StoryResource) Events(params EventsParams) (resp *EventsResponse, err error) {
-	err = s.client.fetch(s.basePath+"/events", params, &resp)
-	return
+func (s StoryResource) Events(params EventsParams) (*EventsResponse, error) {
+	return s.EventsContext(context.Background(), params)
+}
+
+// EventsContext is like Events, with ctx controlling cancellation and deadlines.
+func (s StoryResource) EventsContext(ctx context.Context, params EventsParams) (*EventsResponse, error) {
+	return get[Event](ctx, s.client, s.basePath+"/events", params)
+}
+
+// AllEvents returns an iterator over all Events associated with a Story, fetching pages as needed.
+func (s StoryResource) AllEvents(ctx context.Context, params EventsParams, opts PageOptions) iter.Seq2[Event, error] {
+	return listAll[Event](ctx, s.client, s.basePath+"/events", params, opts)
 }
 
 // Series issues a request to search for Series associated with a Story.
-func (s StoryResource) Series(params SeriesParams) (resp *SeriesResponse, err error) {
-	err = s.client.fetch(s.basePath+"/series", params, &resp)
-	return
+func (s StoryResource) Series(params SeriesParams) (*SeriesResponse, error) {
+	return s.SeriesContext(context.Background(), params)
+}
+
+// SeriesContext is like Series, with ctx controlling cancellation and deadlines.
+func (s StoryResource) SeriesContext(ctx context.Context, params SeriesParams) (*SeriesResponse, error) {
+	return get[Series](ctx, s.client, s.basePath+"/series", params)
+}
+
+// AllSeries returns an iterator over all Series associated with a Story, fetching pages as needed.
+func (s StoryResource) AllSeries(ctx context.Context, params SeriesParams, opts PageOptions) iter.Seq2[Series, error] {
+	return listAll[Series](ctx, s.client, s.basePath+"/series", params, opts)
 }
 
 // StoriesParams represents parameters to search for Stories.
 type StoriesParams struct {
 	CommonParams
-	Comics     []int `url:"comics,omitempty,comma"`
-	Events     []int `url:"events,omitempty,comma"`
-	Creators   []int `url:"creators,omitempty,comma"`
-	Characters []int `url:"characters,omitempty,comma"`
+	OrderBy    []StoryOrder `url:"orderBy,omitempty,comma"`
+	Comics     []int        `url:"comics,omitempty,comma"`
+	Events     []int        `url:"events,omitempty,comma"`
+	Creators   []int        `url:"creators,omitempty,comma"`
+	Characters []int        `url:"characters,omitempty,comma"`
 }
 
+// StoryOrder is a field by which Stories can be ordered.
+// Orderings ending in Desc sort in descending order.
+type StoryOrder string
+
+// Orderings for Stories.
+const (
+	StoryOrderID           StoryOrder = "id"
+	StoryOrderIDDesc       StoryOrder = "-id"
+	StoryOrderModified     StoryOrder = "modified"
+	StoryOrderModifiedDesc StoryOrder = "-modified"
+)
+
 // StoriesResponse represents responses to methods that return Stories.
-type StoriesResponse struct {
-	CommonResponse
-	Data struct {
-		CommonList
-		Results []Story `json:"results,omitempty"`
-	} `json:"data,omitempty"`
-}
+type StoriesResponse = Response[Story]
 
 // Story represents a single Story.
 type Story struct {
@@ -844,30 +1932,84 @@ type Story struct {
 	Title         *string         `json:"title,omitempty"`
 	Description   *string         `json:"description,omitempty"`
 	Type          *string         `json:"type,omitempty"`
-	Modified      *Date           `json:"date,omitempty"`
-	Thumbnail     *Image          `json:"image,omitempty"`
+	Modified      *Date           `json:"modified,omitempty"`
+	Thumbnail     *Image          `json:"thumbnail,omitempty"`
 	Comics        *ComicsList     `json:"comics,omitempty"`
 	Series        *SeriesList     `json:"series,omitempty"`
 	Events        *EventsList     `json:"events,omitempty"`
 	Characters    *CharactersList `json:"characters,omitempty"`
 	Creators      *CreatorsList   `json:"creators,omitempty"`
-	OriginalIssue Comic
+	OriginalIssue *ComicSummary   `json:"originalIssue,omitempty"`
+
+	// Extra holds the fields returned by the API that are not otherwise
+	// decoded, by name.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Get issues a request to get complete information about a Story.
-func (s Story) Get(cl Client) (resp *StoriesResponse, err error) {
-	err = cl.fetch((*s.ResourceURI)[len(basePath):], nil, &resp)
-	return
+func (s Story) Get(cl Client) (*StoriesResponse, error) {
+	return s.GetContext(context.Background(), cl)
+}
+
+// GetContext is like Get, with ctx controlling cancellation and deadlines.
+func (s Story) GetContext(ctx context.Context, cl Client) (*StoriesResponse, error) {
+	path, err := cl.resourcePath(s.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Story](ctx, cl, path, nil)
+}
+
+// StorySummary is the minimal representation of a Story included in lists
+// and references from other entities. Use Fetch to get complete information.
+type StorySummary struct {
+	ResourceURI *string `json:"resourceURI,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	// Type is the type of the story, e.g. "cover" or "interiorStory".
+	Type *string `json:"type,omitempty"`
+}
+
+// ID returns the ID of the Story, from its resource URI.
+func (s StorySummary) ID() (int, error) {
+	return uriID(s.ResourceURI)
+}
+
+// Fetch issues a request to get complete information about the Story.
+func (s StorySummary) Fetch(ctx context.Context, cl Client) (*Story, error) {
+	return fetchOne[Story](ctx, cl, s.ResourceURI)
 }
 
 // StoriesList represents a list of Stories.
 type StoriesList struct {
 	ResourceList
-	Items []Story `json:"items,omitempty"`
+	Items []StorySummary `json:"items,omitempty"`
 }
 
 // List issues a request to get complete information about a list of Stories.
-func (l StoriesList) List(cl Client) (resp *StoriesResponse, err error) {
-	err = cl.fetch((*l.CollectionURI)[len(basePath):], nil, &resp)
-	return
+func (l StoriesList) List(cl Client) (*StoriesResponse, error) {
+	return l.ListContext(context.Background(), cl)
+}
+
+// ListContext is like List, with ctx controlling cancellation and deadlines.
+func (l StoriesList) ListContext(ctx context.Context, cl Client) (*StoriesResponse, error) {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return nil, err
+	}
+	return get[Story](ctx, cl, path, nil)
+}
+
+// All returns an iterator over the complete Stories in the list's collection
+// matching params, fetching pages as needed.
+func (l StoriesList) All(ctx context.Context, cl Client, params StoriesParams, opts PageOptions) iter.Seq2[Story, error] {
+	path, err := cl.resourcePath(l.CollectionURI)
+	if err != nil {
+		return fail[Story](err)
+	}
+	return listAll[Story](ctx, cl, path, params, opts)
+}
+
+// Expand returns every complete Story in the list's collection matching params.
+func (l StoriesList) Expand(ctx context.Context, cl Client, params StoriesParams) ([]Story, error) {
+	return collect(l.All(ctx, cl, params, PageOptions{}))
 }
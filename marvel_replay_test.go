@@ -0,0 +1,104 @@
+package marvel_test
+
+import (
+	"flag"
+	"net/http"
+	"reflect"
+	"testing"
+
+	marvel "github.com/imjasonh/go-marvel"
+	"github.com/imjasonh/go-marvel/marveltest"
+)
+
+var (
+	apiKey = flag.String("pub", "", "Public API key")
+	secret = flag.String("priv", "", "Private API secret")
+	record = flag.Bool("record", false, "Record cassettes against the live API, using -pub and -priv")
+)
+
+// recordingClient returns a Client whose requests are replayed from the
+// named cassette in testdata, or recorded to it if -record is set.
+func recordingClient(t *testing.T, name string) marvel.Client {
+	mode := marveltest.Replay
+	if *record {
+		if *apiKey == "" || *secret == "" {
+			t.Fatal("-record requires -pub and -priv")
+		}
+		mode = marveltest.Record
+	}
+	rec, err := marveltest.NewRecorder("testdata/"+name+".json", mode, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := rec.Save(); err != nil {
+			t.Errorf("Save: %v", err)
+		}
+	})
+	return marvel.Client{
+		PublicKey:  *apiKey,
+		PrivateKey: *secret,
+		Client:     &http.Client{Transport: rec},
+	}
+}
+
+func TestRequest(t *testing.T) {
+	c := recordingClient(t, t.Name())
+	c.Strict = &marvel.StrictDecoding{Warn: func(err *marvel.UnknownFieldsError) { t.Error(err) }}
+
+	r, err := c.SingleSeries(2258).Comics(marvel.ComicsParams{})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if len(r.Data.Results) != 3 {
+		t.Fatalf("got %d comics, want 3", len(r.Data.Results))
+	}
+	for i, iss := range r.Data.Results {
+		if want := float64(141 + i); *iss.IssueNumber != want {
+			t.Errorf("IssueNumber = %v, want %v", *iss.IssueNumber, want)
+		}
+		if !iss.Modified.IsKnown() {
+			t.Errorf("issue %v: Modified is unknown", *iss.IssueNumber)
+		}
+	}
+	first := r.Data.Results[0]
+	if got, want := first.Thumbnail.URL(marvel.PortraitIncredible), "http://i.annihil.us/u/prod/marvel/i/mg/b/40/image_not_available/portrait_incredible.jpg"; got != want {
+		t.Errorf("thumbnail URL = %q, want %q", got, want)
+	}
+	if got, want := *first.Creators.CollectionURI, "http://gateway.marvel.com/v1/public/comics/12400/creators"; got != want {
+		t.Errorf("creators collectionURI = %q, want %q", got, want)
+	}
+	if len(first.Creators.Items) != 2 || *first.Creators.Items[0].Name != "Chris Claremont" || *first.Creators.Items[0].Role != "writer" {
+		t.Errorf("creators = %+v", first.Creators.Items)
+	}
+
+	comic, err := first.Get(c)
+	if err != nil {
+		t.Fatalf("error getting: %v", err)
+	}
+	got := comic.Data.Results[0]
+	if *got.ID != 12400 || *got.Title != "Uncanny X-Men (1963) #141" {
+		t.Errorf("got comic %d %q", *got.ID, *got.Title)
+	}
+	if d, ok := got.OnSaleDate(); !ok || d.Parse().Year() != 1981 {
+		t.Errorf("OnSaleDate() = %v, %t", d, ok)
+	}
+	if p, ok := got.PrintPrice(); !ok || p != 0.5 {
+		t.Errorf("PrintPrice() = %v, %t, want 0.5", p, ok)
+	}
+
+	stories, err := first.Stories.List(c)
+	if err != nil {
+		t.Fatalf("error listing stories: %v", err)
+	}
+	var types []string
+	for _, s := range stories.Data.Results {
+		types = append(types, *s.Type)
+		if s.OriginalIssue == nil || *s.OriginalIssue.Name != "Uncanny X-Men (1963) #141" {
+			t.Errorf("story %d: OriginalIssue = %+v", *s.ID, s.OriginalIssue)
+		}
+	}
+	if want := []string{"cover", "interiorStory"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("story types = %v, want %v", types, want)
+	}
+}
@@ -1,43 +1,243 @@
 package marvel
 
 import (
-	"flag"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"testing"
-)
+	"time"
 
-var (
-	apiKey = flag.String("pub", "", "Public API key")
-	secret = flag.String("priv", "", "Private API secret")
+	"github.com/google/go-querystring/query"
 )
 
-func TestRequest(t *testing.T) {
-	flag.Parse()
+func TestRequestContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
 
-	c := Client{
-		PublicKey:  *apiKey,
-		PrivateKey: *secret,
+	c := Client{PublicKey: "pub", PrivateKey: "priv"}
+	if _, err := c.SingleSeries(2258).ComicsContext(ctx, ComicsParams{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
 	}
-	r, err := c.SingleSeries(2258).Comics(ComicsParams{})
-	if err != nil {
-		t.Errorf("error: %v", err)
-		return
+}
+
+// testClient returns a Client whose requests are served by h.
+func testClient(t *testing.T, h http.Handler) Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return Client{
+		PublicKey:  "pub",
+		PrivateKey: "priv",
+		BaseURL:    srv.URL + "/v1/public",
 	}
-	t.Logf("%+v", r.Data)
-	for _, iss := range r.Data.Results {
-		t.Logf("%v %s", *iss.IssueNumber, iss.Modified.Parse().String())
-		t.Logf(iss.Thumbnail.URL(PortraitIncredible))
+}
+
+func TestBaseURL(t *testing.T) {
+	var paths []string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"code":200}`))
+	}))
+
+	ctx := context.Background()
+	uri := "http://gateway.marvel.com/v1/public/comics/21366"
+	if _, err := (Comic{ResourceURI: &uri}).GetContext(ctx, c); err != nil {
+		t.Fatalf("GetContext: %v", err)
 	}
-	comic, err := r.Data.Results[0].Get(c)
-	if err != nil {
-		t.Errorf("error getting: %v", err)
-		return
+	uri = "https://gateway.marvel.com/v1/public/comics/21366/stories"
+	if _, err := (StoriesList{ResourceList: ResourceList{CollectionURI: &uri}}).ListContext(ctx, c); err != nil {
+		t.Fatalf("ListContext: %v", err)
+	}
+	uri = c.BaseURL + "/events/116"
+	if _, err := (Event{ResourceURI: &uri}).GetContext(ctx, c); err != nil {
+		t.Fatalf("GetContext: %v", err)
+	}
+	if _, err := c.Comic(21366).GetContext(ctx); err != nil {
+		t.Fatalf("GetContext: %v", err)
+	}
+	want := []string{
+		"/v1/public/comics/21366",
+		"/v1/public/comics/21366/stories",
+		"/v1/public/events/116",
+		"/v1/public/comics/21366",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got paths %q, want %q", paths, want)
+	}
+
+	for _, uri := range []*string{nil, strPtr("http://example.com/v1/public/comics/1")} {
+		if _, err := (Comic{ResourceURI: uri}).GetContext(ctx, c); err == nil {
+			t.Errorf("GetContext with URI %v succeeded", uri)
+		}
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestDate(t *testing.T) {
+	for _, tc := range []struct {
+		in        string
+		wantKnown bool
+		wantTime  time.Time
+	}{
+		{`"2013-09-16T15:35:21-0400"`, true, time.Date(2013, 9, 16, 19, 35, 21, 0, time.UTC)},
+		{`"-0001-11-30T00:00:00-0500"`, false, time.Time{}},
+		{`"0000-00-00T00:00:00-0500"`, false, time.Time{}},
+		{`"1969-12-31T19:00:00-0500"`, false, time.Time{}},
+		{`""`, false, time.Time{}},
+		{`null`, false, time.Time{}},
+	} {
+		var d Date
+		if err := json.Unmarshal([]byte(tc.in), &d); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tc.in, err)
+			continue
+		}
+		if d.IsKnown() != tc.wantKnown {
+			t.Errorf("Unmarshal(%s).IsKnown() = %t, want %t", tc.in, d.IsKnown(), tc.wantKnown)
+		}
+		if !d.Time.Equal(tc.wantTime) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tc.in, d.Time, tc.wantTime)
+		}
+		out, err := json.Marshal(d)
+		if err != nil {
+			t.Errorf("Marshal(%s): %v", tc.in, err)
+		} else if string(out) != tc.in {
+			t.Errorf("round trip of %s = %s", tc.in, out)
+		}
+	}
+
+	var c Comic
+	if err := json.Unmarshal([]byte(`{"modified":"yesterday"}`), &c); err == nil {
+		t.Errorf("Unmarshal of invalid date succeeded")
+	}
+}
+
+func TestDateParams(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	var got url.Values
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Write([]byte(`{"code":200}`))
+	}))
+	ctx := context.Background()
+	if _, err := c.ComicsContext(ctx, ComicsParams{
+		CommonParams: CommonParams{ModifiedSince: time.Date(2014, 1, 2, 3, 4, 5, 0, est)},
+		DateRange: DateRange{
+			Start: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2014, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}); err != nil {
+		t.Fatalf("ComicsContext: %v", err)
+	}
+	if want := "2014-01-02T03:04:05-0500"; got.Get("modifiedSince") != want {
+		t.Errorf("modifiedSince = %q, want %q", got.Get("modifiedSince"), want)
+	}
+	if want := "2014-01-01,2014-02-01"; got.Get("dateRange") != want {
+		t.Errorf("dateRange = %q, want %q", got.Get("dateRange"), want)
 	}
-	t.Logf("%+v", comic.Data.Results[0])
 
-	stories, err := r.Data.Results[0].Stories.List(c)
+	got = nil
+	for _, r := range []DateRange{
+		{Start: time.Date(2014, 2, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2014, 2, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		if _, err := c.ComicsContext(ctx, ComicsParams{DateRange: r}); err == nil {
+			t.Errorf("ComicsContext with DateRange %v succeeded", r)
+		}
+	}
+	if got != nil {
+		t.Errorf("invalid DateRange was sent to the API")
+	}
+}
+
+func TestEnumParams(t *testing.T) {
+	for _, tc := range []struct {
+		params interface{}
+		want   string
+	}{{
+		ComicsParams{
+			OrderBy:        []ComicOrder{ComicOrderOnSaleDateDesc, ComicOrderIssueNumber},
+			Format:         FormatTradePaperback,
+			FormatType:     FormatTypeCollection,
+			DateDescriptor: ThisMonth,
+		},
+		"dateDescriptor=thisMonth&format=trade+paperback&formatType=collection&orderBy=-onsaleDate%2CissueNumber",
+	}, {
+		SeriesParams{
+			OrderBy:    []SeriesOrder{SeriesOrderStartYearDesc},
+			SeriesType: SeriesTypeOneShot,
+			Contains:   []Format{FormatComic, FormatDigitalComic},
+		},
+		"contains=comic%2Cdigital+comic&orderBy=-startYear&seriesType=one+shot",
+	}} {
+		q, err := query.Values(tc.params)
+		if err != nil {
+			t.Errorf("query.Values(%+v): %v", tc.params, err)
+		} else if got := q.Encode(); got != tc.want {
+			t.Errorf("query.Values(%+v) = %q, want %q", tc.params, got, tc.want)
+		}
+	}
+}
+
+func TestComicMetadata(t *testing.T) {
+	var c Comic
+	if err := json.Unmarshal([]byte(`{
+		"textObjects": [
+			{"type": "issue_preview_text", "language": "en-us", "text": "Preview"},
+			{"type": "issue_solicit_text", "language": "en-us", "text": "Solicit"}
+		],
+		"dates": [
+			{"type": "onsaleDate", "date": "2014-05-07T00:00:00-0400"},
+			{"type": "focDate", "date": "2014-04-14T00:00:00-0400"}
+		],
+		"prices": [
+			{"type": "printPrice", "price": 3.99},
+			{"type": "digitalPurchasePrice", "price": 0}
+		]
+	}`), &c); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if d, ok := c.OnSaleDate(); !ok || d.Format("2006-01-02") != "2014-05-07" {
+		t.Errorf("OnSaleDate() = %v, %t, want 2014-05-07", d, ok)
+	}
+	if p, ok := c.PrintPrice(); !ok || p != 3.99 {
+		t.Errorf("PrintPrice() = %v, %t, want 3.99", p, ok)
+	}
+	if p, ok := c.DigitalPurchasePrice(); !ok || p != 0 {
+		t.Errorf("DigitalPurchasePrice() = %v, %t, want 0", p, ok)
+	}
+	// Free comics keep their price when re-encoded, e.g. by marveltest.
+	b, err := json.Marshal(c.Prices[1])
 	if err != nil {
-		t.Errorf("error listing stories: %v", err)
-		return
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `{"type":"digitalPurchasePrice","price":0}`; string(b) != want {
+		t.Errorf("Marshal(%+v) = %s, want %s", c.Prices[1], b, want)
+	}
+	if _, ok := c.DateOf(ComicDateUnlimited); ok {
+		t.Errorf("DateOf(ComicDateUnlimited) found a date")
+	}
+	if got := c.SolicitText("EN-US"); got != "Solicit" {
+		t.Errorf("SolicitText(EN-US) = %q, want Solicit", got)
+	}
+	if got := c.SolicitText("fr"); got != "" {
+		t.Errorf("SolicitText(fr) = %q, want empty", got)
+	}
+
+	var ch Character
+	if err := json.Unmarshal([]byte(`{"urls": [
+		{"type": "detail", "url": "http://marvel.com/characters/25/hulk"},
+		{"type": "wiki", "url": "http://marvel.com/universe/Hulk_(Bruce_Banner)"}
+	]}`), &ch); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got, want := ch.WikiURL(), "http://marvel.com/universe/Hulk_(Bruce_Banner)"; got != want {
+		t.Errorf("WikiURL() = %q, want %q", got, want)
+	}
+	if got := ch.ComicLinkURL(); got != "" {
+		t.Errorf("ComicLinkURL() = %q, want empty", got)
 	}
-	t.Logf("%+v", stories.Data.Results)
 }
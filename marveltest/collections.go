@@ -0,0 +1,518 @@
+package marveltest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	marvel "github.com/imjasonh/go-marvel"
+)
+
+// entry describes an entity for filtering and ordering.
+type entry struct {
+	ref      ref
+	modified time.Time
+	// fields holds the entity's values for equality and prefix filters.
+	fields map[string]string
+	// keys holds the entity's values for ordering, which are strings,
+	// float64s, ints or time.Times.
+	keys map[string]interface{}
+	// links holds the IDs of linked entities, by kind.
+	links map[string]map[int]bool
+}
+
+// collection describes the parameters accepted when searching a collection.
+type collection struct {
+	// equal maps parameters to the fields they must equal.
+	equal map[string]string
+	// startsWith maps parameters to the fields they must prefix.
+	startsWith map[string]string
+	// links maps parameters to the kind of entity whose IDs they list; an
+	// entity matches if it is linked to any of them.
+	links map[string]string
+	// linksAll maps parameters to the kind of entity whose IDs they list; an
+	// entity matches if it is linked to all of them.
+	linksAll map[string]string
+	// special holds filters that need custom handling.
+	special map[string]func(s *Server, v string) (func(entry) bool, error)
+	// orderBy lists the fields results may be ordered by.
+	orderBy []string
+}
+
+var collections = map[string]collection{
+	"characters": {
+		equal:      map[string]string{"name": "name"},
+		startsWith: map[string]string{"nameStartsWith": "name"},
+		links:      map[string]string{"comics": "comics", "series": "series", "events": "events", "stories": "stories"},
+		orderBy:    []string{"name", "modified"},
+	},
+	"comics": {
+		equal: map[string]string{
+			"format": "format", "formatType": "formatType", "title": "title",
+			"issueNumber": "issueNumber", "diamondCode": "diamondCode", "digitalId": "digitalId",
+			"upc": "upc", "isbn": "isbn", "ean": "ean", "issn": "issn",
+		},
+		startsWith: map[string]string{"titleStartsWith": "title"},
+		links:      map[string]string{"creators": "creators", "characters": "characters", "series": "series", "events": "events", "stories": "stories"},
+		linksAll:   map[string]string{"sharedAppearances": "characters", "collaborators": "creators"},
+		special: map[string]func(*Server, string) (func(entry) bool, error){
+			"noVariants":      boolFilter("noVariants", "variantDescription", false),
+			"hasDigitalIssue": boolFilter("hasDigitalIssue", "digitalId", true),
+			"dateDescriptor":  dateDescriptorFilter,
+			"dateRange":       dateRangeFilter,
+		},
+		orderBy: []string{"focDate", "onsaleDate", "title", "issueNumber", "modified"},
+	},
+	"creators": {
+		equal: map[string]string{"firstName": "firstName", "middleName": "middleName", "lastName": "lastName", "suffix": "suffix"},
+		startsWith: map[string]string{
+			"nameStartsWith": "fullName", "firstNameStartsWith": "firstName",
+			"middleNameStartsWith": "middleName", "lastNameStartsWith": "lastName",
+		},
+		links:   map[string]string{"comics": "comics", "series": "series", "events": "events", "stories": "stories"},
+		orderBy: []string{"lastName", "firstName", "middleName", "suffix", "modified"},
+	},
+	"events": {
+		equal:      map[string]string{"name": "title"},
+		startsWith: map[string]string{"nameStartsWith": "title"},
+		links:      map[string]string{"creators": "creators", "characters": "characters", "series": "series", "comics": "comics", "stories": "stories"},
+		orderBy:    []string{"name", "startDate", "modified"},
+	},
+	"series": {
+		equal:      map[string]string{"title": "title", "startYear": "startYear", "seriesType": "type"},
+		startsWith: map[string]string{"titleStartsWith": "title"},
+		links:      map[string]string{"comics": "comics", "stories": "stories", "events": "events", "creators": "creators", "characters": "characters"},
+		special: map[string]func(*Server, string) (func(entry) bool, error){
+			"contains": containsFilter,
+		},
+		orderBy: []string{"title", "modified", "startYear"},
+	},
+	"stories": {
+		links:   map[string]string{"comics": "comics", "series": "series", "events": "events", "creators": "creators", "characters": "characters"},
+		orderBy: []string{"id", "modified"},
+	},
+}
+
+// filter returns a predicate implementing the parameter name with value v,
+// or nil if the parameter does not filter results.
+func (c collection) filter(s *Server, name, v string) (func(entry) bool, error) {
+	if name == "orderBy" {
+		return nil, nil
+	}
+	if v == "" {
+		return nil, invalidParam("%s cannot be blank if it is set.", name)
+	}
+	if name == "modifiedSince" {
+		t, ok := parseTime(v)
+		if !ok {
+			return nil, invalidParam("You must pass a valid date for modifiedSince.")
+		}
+		return func(e entry) bool { return !e.modified.Before(t) }, nil
+	}
+	if f, ok := c.equal[name]; ok {
+		return func(e entry) bool { return strings.EqualFold(e.fields[f], v) }, nil
+	}
+	if f, ok := c.startsWith[name]; ok {
+		return func(e entry) bool { return strings.HasPrefix(strings.ToLower(e.fields[f]), strings.ToLower(v)) }, nil
+	}
+	if kind, ok := c.links[name]; ok {
+		ids, err := parseIDs(name, v)
+		if err != nil {
+			return nil, err
+		}
+		return func(e entry) bool {
+			for _, id := range ids {
+				if e.links[kind][id] {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+	if kind, ok := c.linksAll[name]; ok {
+		ids, err := parseIDs(name, v)
+		if err != nil {
+			return nil, err
+		}
+		return func(e entry) bool {
+			for _, id := range ids {
+				if !e.links[kind][id] {
+					return false
+				}
+			}
+			return true
+		}, nil
+	}
+	if f, ok := c.special[name]; ok {
+		return f(s, v)
+	}
+	return nil, invalidParam("We don't recognize the parameter %s", name)
+}
+
+func parseIDs(name, v string) ([]int, error) {
+	parts := strings.Split(v, ",")
+	if len(parts) > 10 {
+		return nil, invalidParam("You may not submit more than 10 %s ids.", name)
+	}
+	var ids []int
+	for _, p := range parts {
+		id, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, invalidParam("You must pass a comma-separated list of integer ids for %s.", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// boolFilter returns a filter for a boolean parameter that, when true,
+// requires field to be non-empty (or empty, if nonEmpty is false).
+func boolFilter(name, field string, nonEmpty bool) func(*Server, string) (func(entry) bool, error) {
+	return func(_ *Server, v string) (func(entry) bool, error) {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, invalidParam("You must pass a boolean for %s.", name)
+		}
+		if !b {
+			return nil, nil
+		}
+		return func(e entry) bool {
+			f := e.fields[field]
+			return (f != "" && f != "0") == nonEmpty
+		}, nil
+	}
+}
+
+func dateDescriptorFilter(s *Server, v string) (func(entry) bool, error) {
+	now := s.now()
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	weekStart := today.AddDate(0, 0, -int(today.Weekday()))
+	var start, end time.Time
+	switch v {
+	case "lastWeek":
+		start, end = weekStart.AddDate(0, 0, -7), weekStart
+	case "thisWeek":
+		start, end = weekStart, weekStart.AddDate(0, 0, 7)
+	case "nextWeek":
+		start, end = weekStart.AddDate(0, 0, 7), weekStart.AddDate(0, 0, 14)
+	case "thisMonth":
+		start = time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
+		end = start.AddDate(0, 1, 0)
+	default:
+		return nil, invalidParam("%s is not a valid dateDescriptor.", v)
+	}
+	return func(e entry) bool {
+		t, _ := e.keys["onsaleDate"].(time.Time)
+		return !t.Before(start) && t.Before(end)
+	}, nil
+}
+
+func dateRangeFilter(_ *Server, v string) (func(entry) bool, error) {
+	parts := strings.Split(v, ",")
+	if len(parts) != 2 {
+		return nil, invalidParam("You must pass two dates separated by a comma for dateRange.")
+	}
+	start, ok1 := parseTime(parts[0])
+	end, ok2 := parseTime(parts[1])
+	if !ok1 || !ok2 {
+		return nil, invalidParam("You must pass valid dates for dateRange.")
+	}
+	if end.Before(start) {
+		return nil, invalidParam("The start of dateRange must precede its end.")
+	}
+	return func(e entry) bool {
+		t, _ := e.keys["onsaleDate"].(time.Time)
+		return !t.Before(start) && !t.After(end)
+	}, nil
+}
+
+// containsFilter matches series containing comics in any of the given formats.
+func containsFilter(s *Server, v string) (func(entry) bool, error) {
+	formats := strings.Split(v, ",")
+	return func(e entry) bool {
+		for id := range e.links["comics"] {
+			c, ok := s.entities[ref{"comics", id}].(marvel.Comic)
+			if !ok {
+				continue
+			}
+			for _, f := range formats {
+				if strings.EqualFold(str(c.Format), f) {
+					return true
+				}
+			}
+		}
+		return false
+	}, nil
+}
+
+// sort orders entries by a comma-separated list of fields, each optionally
+// prefixed by "-" to sort in descending order. Entries are otherwise ordered
+// by ID.
+func (c collection) sort(entries []entry, orderBy string) error {
+	type key struct {
+		field string
+		desc  bool
+	}
+	var keys []key
+	if orderBy != "" {
+		for _, f := range strings.Split(orderBy, ",") {
+			k := key{field: strings.TrimPrefix(f, "-"), desc: strings.HasPrefix(f, "-")}
+			if !contains(c.orderBy, k.field) {
+				return invalidParam("%s is not a valid ordering parameter.", f)
+			}
+			keys = append(keys, k)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		for _, k := range keys {
+			if n := compare(a.keys[k.field], b.keys[k.field]); n != 0 {
+				return (n < 0) != k.desc
+			}
+		}
+		return a.ref.id < b.ref.id
+	})
+	return nil
+}
+
+// compare compares two ordering keys of the same type.
+func compare(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		b, _ := b.(string)
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	case float64:
+		b, _ := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case int:
+		b, _ := b.(int)
+		return a - b
+	case time.Time:
+		b, _ := b.(time.Time)
+		return a.Compare(b)
+	case nil:
+		if b != nil {
+			return -1
+		}
+	}
+	return 0
+}
+
+// describe returns the entry describing the entity r.
+func (s *Server) describe(r ref) entry {
+	e := entry{
+		ref:    r,
+		fields: map[string]string{},
+		keys:   map[string]interface{}{"id": r.id},
+		links:  map[string]map[int]bool{},
+	}
+	for l := range s.links[r] {
+		if e.links[l.kind] == nil {
+			e.links[l.kind] = map[int]bool{}
+		}
+		e.links[l.kind][l.id] = true
+	}
+	set := func(field, v string) {
+		e.fields[field] = v
+		e.keys[field] = v
+	}
+	switch v := s.entities[r].(type) {
+	case marvel.Character:
+		e.modified = parseDate(v.Modified)
+		set("name", str(v.Name))
+	case marvel.Comic:
+		e.modified = parseDate(v.Modified)
+		set("title", str(v.Title))
+		set("format", str(v.Format))
+		set("diamondCode", str(v.DiamondCode))
+		set("upc", str(v.UPC))
+		set("isbn", str(v.ISBN))
+		set("ean", str(v.EAN))
+		set("issn", str(v.ISSN))
+		set("digitalId", itoa(v.DigitalID))
+		set("variantDescription", str(v.VariantDescription))
+		formatType := "comic"
+		if f := strings.ToLower(str(v.Format)); f == "trade paperback" || f == "hardcover" {
+			formatType = "collection"
+		}
+		set("formatType", formatType)
+		if v.IssueNumber != nil {
+			e.fields["issueNumber"] = strconv.FormatFloat(*v.IssueNumber, 'f', -1, 64)
+			e.keys["issueNumber"] = *v.IssueNumber
+		}
+		for _, d := range v.Dates {
+			date := d.Date
+			e.keys[string(d.Type)] = parseDate(&date)
+		}
+	case marvel.Creator:
+		e.modified = parseDate(v.Modified)
+		set("firstName", str(v.FirstName))
+		set("middleName", str(v.MiddleName))
+		set("lastName", str(v.LastName))
+		set("suffix", str(v.Suffix))
+		fullName := str(v.FullName)
+		if fullName == "" {
+			fullName = str(v.Name)
+		}
+		set("fullName", fullName)
+	case marvel.Event:
+		e.modified = parseDate(v.Modified)
+		set("title", str(v.Title))
+		e.keys["name"] = str(v.Title)
+		e.keys["startDate"] = parseDate(v.Start)
+	case marvel.Series:
+		e.modified = parseDate(v.Modified)
+		set("title", str(v.Title))
+		var seriesType marvel.SeriesType
+		if v.Type != nil {
+			seriesType = *v.Type
+		}
+		set("type", string(seriesType))
+		set("startYear", itoa(v.StartYear))
+		if v.StartYear != nil {
+			e.keys["startYear"] = *v.StartYear
+		}
+	case marvel.Story:
+		e.modified = parseDate(v.Modified)
+	}
+	e.keys["modified"] = e.modified
+	return e
+}
+
+// name returns the name of an entity, as used in resource list summaries.
+func (s *Server) name(r ref) *string {
+	switch v := s.entities[r].(type) {
+	case marvel.Character:
+		return v.Name
+	case marvel.Comic:
+		return v.Title
+	case marvel.Creator:
+		if v.FullName != nil {
+			return v.FullName
+		}
+		return v.Name
+	case marvel.Event:
+		return v.Title
+	case marvel.Series:
+		return v.Title
+	case marvel.Story:
+		return v.Title
+	}
+	return nil
+}
+
+// summary returns the ResourceList for the entities of a kind linked to r,
+// and references to up to maxItems of them.
+func (s *Server) summary(r ref, kind string) (marvel.ResourceList, []ref) {
+	var refs []ref
+	for l := range s.links[r] {
+		if _, ok := s.entities[l]; ok && l.kind == kind {
+			refs = append(refs, l)
+		}
+	}
+	sortRefs(refs)
+	available := len(refs)
+	if len(refs) > maxItems {
+		refs = refs[:maxItems]
+	}
+	returned := len(refs)
+	uri := str(uriOf(r)) + "/" + kind
+	return marvel.ResourceList{Available: &available, Returned: &returned, CollectionURI: &uri}, refs
+}
+
+func (s *Server) characters(r ref) *marvel.CharactersList {
+	rl, refs := s.summary(r, "characters")
+	l := &marvel.CharactersList{ResourceList: rl}
+	for _, i := range refs {
+		l.Items = append(l.Items, marvel.CharacterSummary{ResourceURI: uriOf(i), Name: s.name(i)})
+	}
+	return l
+}
+
+func (s *Server) comics(r ref) *marvel.ComicsList {
+	rl, refs := s.summary(r, "comics")
+	l := &marvel.ComicsList{ResourceList: rl}
+	for _, i := range refs {
+		l.Items = append(l.Items, marvel.ComicSummary{ResourceURI: uriOf(i), Name: s.name(i)})
+	}
+	return l
+}
+
+func (s *Server) creators(r ref) *marvel.CreatorsList {
+	rl, refs := s.summary(r, "creators")
+	l := &marvel.CreatorsList{ResourceList: rl}
+	for _, i := range refs {
+		l.Items = append(l.Items, marvel.CreatorSummary{ResourceURI: uriOf(i), Name: s.name(i)})
+	}
+	return l
+}
+
+func (s *Server) events(r ref) *marvel.EventsList {
+	rl, refs := s.summary(r, "events")
+	l := &marvel.EventsList{ResourceList: rl}
+	for _, i := range refs {
+		l.Items = append(l.Items, marvel.EventSummary{ResourceURI: uriOf(i), Name: s.name(i)})
+	}
+	return l
+}
+
+func (s *Server) series(r ref) *marvel.SeriesList {
+	rl, refs := s.summary(r, "series")
+	l := &marvel.SeriesList{ResourceList: rl}
+	for _, i := range refs {
+		l.Items = append(l.Items, marvel.SeriesSummary{ResourceURI: uriOf(i), Name: s.name(i)})
+	}
+	return l
+}
+
+func (s *Server) stories(r ref) *marvel.StoriesList {
+	rl, refs := s.summary(r, "stories")
+	l := &marvel.StoriesList{ResourceList: rl}
+	for _, i := range refs {
+		l.Items = append(l.Items, marvel.StorySummary{ResourceURI: uriOf(i), Name: s.name(i)})
+	}
+	return l
+}
+
+// render returns the entity r as served by the API, with its resource URI,
+// ID and resource lists filled in from its links.
+func (s *Server) render(r ref) interface{} {
+	id := r.id
+	switch v := s.entities[r].(type) {
+	case marvel.Character:
+		v.ResourceURI, v.ID = uriOf(r), &id
+		v.Comics, v.Stories, v.Events, v.Series = s.comics(r), s.stories(r), s.events(r), s.series(r)
+		return v
+	case marvel.Comic:
+		v.ResourceURI, v.ID = uriOf(r), &id
+		v.Creators, v.Characters, v.Stories, v.Events = s.creators(r), s.characters(r), s.stories(r), s.events(r)
+		if series := s.series(r); len(series.Items) > 0 {
+			v.Series = &series.Items[0]
+		}
+		return v
+	case marvel.Creator:
+		v.ResourceURI, v.ID = uriOf(r), &id
+		v.Series, v.Stories, v.Comics, v.Events = s.series(r), s.stories(r), s.comics(r), s.events(r)
+		return v
+	case marvel.Event:
+		v.ResourceURI, v.ID = uriOf(r), &id
+		v.Comics, v.Stories, v.Series, v.Characters, v.Creators = s.comics(r), s.stories(r), s.series(r), s.characters(r), s.creators(r)
+		return v
+	case marvel.Series:
+		v.ResourceURI, v.ID = uriOf(r), &id
+		v.Comics, v.Stories, v.Events, v.Characters, v.Creators = s.comics(r), s.stories(r), s.events(r), s.characters(r), s.creators(r)
+		return v
+	case marvel.Story:
+		v.ResourceURI, v.ID = uriOf(r), &id
+		v.Comics, v.Series, v.Events, v.Characters, v.Creators = s.comics(r), s.series(r), s.events(r), s.characters(r), s.creators(r)
+		return v
+	}
+	return nil
+}
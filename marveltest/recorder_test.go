@@ -0,0 +1,57 @@
+package marveltest
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	marvel "github.com/imjasonh/go-marvel"
+)
+
+func TestRecorder(t *testing.T) {
+	s := newServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	rec, err := NewRecorder(path, Record, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	c := s.Client()
+	c.Client = &http.Client{Transport: rec}
+	if _, err := c.CharactersContext(ctx, marvel.CharactersParams{Name: "Hulk"}); err != nil {
+		t.Fatalf("CharactersContext: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// Replay with different keys, after the server has gone away.
+	s.Close()
+	rec, err = NewRecorder(path, Replay, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	c = marvel.Client{
+		PublicKey:  "other",
+		PrivateKey: "keys",
+		BaseURL:    s.BaseURL(),
+		Client:     &http.Client{Transport: rec},
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := c.CharactersContext(ctx, marvel.CharactersParams{Name: "Hulk"})
+		if err != nil {
+			t.Fatalf("CharactersContext: %v", err)
+		}
+		if got := *resp.Data.Results[0].Name; got != "Hulk" {
+			t.Errorf("got %q, want Hulk", got)
+		}
+	}
+
+	_, err = c.CharactersContext(ctx, marvel.CharactersParams{Name: "Thor"})
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("got error %v for unrecorded request, want no recorded response", err)
+	}
+}
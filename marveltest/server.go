@@ -0,0 +1,551 @@
+// Package marveltest provides an in-process fake of the Marvel API for tests.
+package marveltest
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	marvel "github.com/imjasonh/go-marvel"
+)
+
+const (
+	// basePath is the path under which the Server serves the API.
+	basePath = "/v1/public"
+	// canonicalURL is the prefix of resource and collection URIs in responses,
+	// matching those returned by the real API.
+	canonicalURL = "http://gateway.marvel.com" + basePath
+
+	defaultLimit = 20
+	maxLimit     = 100
+	// maxItems is the maximum number of items in a resource list summary.
+	maxItems = 20
+)
+
+// Fixtures holds entities to serve. It can be populated with Go values or
+// decoded from a JSON file with LoadFile.
+//
+// Entities are linked to each other by the resource URIs of the items in
+// their resource lists, e.g. a Comic's Characters; links need only be
+// specified on one side. Each entity must have an ID or a resource URI.
+type Fixtures struct {
+	Characters []marvel.Character `json:"characters,omitempty"`
+	Comics     []marvel.Comic     `json:"comics,omitempty"`
+	Creators   []marvel.Creator   `json:"creators,omitempty"`
+	Events     []marvel.Event     `json:"events,omitempty"`
+	Series     []marvel.Series    `json:"series,omitempty"`
+	Stories    []marvel.Story     `json:"stories,omitempty"`
+}
+
+// Server is a fake Marvel API server.
+//
+// It serves all six entity collections and their sub-collections, honors
+// the documented filtering, ordering and pagination parameters, and verifies
+// request signatures as the real API does.
+type Server struct {
+	*httptest.Server
+
+	// PublicKey and PrivateKey are the keys requests must be signed with.
+	PublicKey, PrivateKey string
+	// Referers are the hosts authorized to make requests with only the
+	// PublicKey, identified by the request's Referer header. A host of the
+	// form "*.example.com" authorizes every subdomain of example.com.
+	Referers []string
+	// Now returns the current time, used to evaluate dateDescriptor filters.
+	// Defaults to time.Now.
+	Now func() time.Time
+
+	mu       sync.RWMutex
+	entities map[ref]interface{}
+	links    map[ref]map[ref]bool
+}
+
+// ref identifies an entity by its collection name and ID.
+type ref struct {
+	kind string
+	id   int
+}
+
+// NewServer starts and returns a new Server with no entities, accepting
+// requests signed with the keys "public" and "private".
+//
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		PublicKey:  "public",
+		PrivateKey: "private",
+		entities:   map[ref]interface{}{},
+		links:      map[ref]map[ref]bool{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the base URL of the fake API, for use as Client.BaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + basePath
+}
+
+// Client returns a Client configured to make requests to the Server.
+func (s *Server) Client() marvel.Client {
+	return marvel.Client{
+		PublicKey:  s.PublicKey,
+		PrivateKey: s.PrivateKey,
+		BaseURL:    s.BaseURL(),
+	}
+}
+
+// LoadFile adds the entities in a JSON-encoded Fixtures file.
+func (s *Server) LoadFile(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var f Fixtures
+	if err := json.Unmarshal(b, &f); err != nil {
+		return fmt.Errorf("marveltest: decoding %s: %v", path, err)
+	}
+	return s.Add(f)
+}
+
+// Add adds entities to the Server, replacing any with the same IDs.
+func (s *Server) Add(f Fixtures) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range f.Characters {
+		if err := s.add("characters", c.ID, c.ResourceURI, c,
+			comicRefs(c.Comics), storyRefs(c.Stories), eventRefs(c.Events), seriesRefs(c.Series)); err != nil {
+			return err
+		}
+	}
+	for _, c := range f.Comics {
+		var series []ref
+		if c.Series != nil {
+			if id, ok := uriID(c.Series.ResourceURI); ok {
+				series = append(series, ref{"series", id})
+			}
+		}
+		if err := s.add("comics", c.ID, c.ResourceURI, c,
+			creatorRefs(c.Creators), characterRefs(c.Characters), storyRefs(c.Stories), eventRefs(c.Events), series); err != nil {
+			return err
+		}
+	}
+	for _, c := range f.Creators {
+		if err := s.add("creators", c.ID, c.ResourceURI, c,
+			seriesRefs(c.Series), storyRefs(c.Stories), comicRefs(c.Comics), eventRefs(c.Events)); err != nil {
+			return err
+		}
+	}
+	for _, e := range f.Events {
+		if err := s.add("events", e.ID, e.ResourceURI, e,
+			comicRefs(e.Comics), storyRefs(e.Stories), seriesRefs(e.Series), characterRefs(e.Characters), creatorRefs(e.Creators)); err != nil {
+			return err
+		}
+	}
+	for _, se := range f.Series {
+		if err := s.add("series", se.ID, se.ResourceURI, se,
+			comicRefs(se.Comics), storyRefs(se.Stories), eventRefs(se.Events), characterRefs(se.Characters), creatorRefs(se.Creators)); err != nil {
+			return err
+		}
+	}
+	for _, st := range f.Stories {
+		var comics []ref
+		if st.OriginalIssue != nil {
+			if id, ok := uriID(st.OriginalIssue.ResourceURI); ok {
+				comics = append(comics, ref{"comics", id})
+			}
+		}
+		if err := s.add("stories", st.ID, st.ResourceURI, st,
+			comicRefs(st.Comics), seriesRefs(st.Series), eventRefs(st.Events), characterRefs(st.Characters), creatorRefs(st.Creators), comics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) add(kind string, id *int, uri *string, v interface{}, links ...[]ref) error {
+	r := ref{kind: kind}
+	if id != nil {
+		r.id = *id
+	} else if i, ok := uriID(uri); ok {
+		r.id = i
+	} else {
+		return fmt.Errorf("marveltest: %s entity has no ID or resource URI", kind)
+	}
+	s.entities[r] = v
+	for _, l := range links {
+		for _, to := range l {
+			s.link(r, to)
+			s.link(to, r)
+		}
+	}
+	return nil
+}
+
+func (s *Server) link(from, to ref) {
+	if s.links[from] == nil {
+		s.links[from] = map[ref]bool{}
+	}
+	s.links[from][to] = true
+}
+
+// uriID returns the ID of the entity identified by a resource URI.
+func uriID(uri *string) (int, bool) {
+	if uri == nil {
+		return 0, false
+	}
+	r, err := marvel.ParseResourceURI(*uri)
+	return r.ID, err == nil && r.Collection == ""
+}
+
+func uriOf(r ref) *string {
+	u := fmt.Sprintf("%s/%s/%d", canonicalURL, r.kind, r.id)
+	return &u
+}
+
+func itemRefs[T any](kind string, items []T, uri func(T) *string) []ref {
+	var refs []ref
+	for _, it := range items {
+		if id, ok := uriID(uri(it)); ok {
+			refs = append(refs, ref{kind, id})
+		}
+	}
+	return refs
+}
+
+func characterRefs(l *marvel.CharactersList) []ref {
+	if l == nil {
+		return nil
+	}
+	return itemRefs("characters", l.Items, func(c marvel.CharacterSummary) *string { return c.ResourceURI })
+}
+
+func comicRefs(l *marvel.ComicsList) []ref {
+	if l == nil {
+		return nil
+	}
+	return itemRefs("comics", l.Items, func(c marvel.ComicSummary) *string { return c.ResourceURI })
+}
+
+func creatorRefs(l *marvel.CreatorsList) []ref {
+	if l == nil {
+		return nil
+	}
+	return itemRefs("creators", l.Items, func(c marvel.CreatorSummary) *string { return c.ResourceURI })
+}
+
+func eventRefs(l *marvel.EventsList) []ref {
+	if l == nil {
+		return nil
+	}
+	return itemRefs("events", l.Items, func(e marvel.EventSummary) *string { return e.ResourceURI })
+}
+
+func seriesRefs(l *marvel.SeriesList) []ref {
+	if l == nil {
+		return nil
+	}
+	return itemRefs("series", l.Items, func(s marvel.SeriesSummary) *string { return s.ResourceURI })
+}
+
+func storyRefs(l *marvel.StoriesList) []ref {
+	if l == nil {
+		return nil
+	}
+	return itemRefs("stories", l.Items, func(s marvel.StorySummary) *string { return s.ResourceURI })
+}
+
+// apiError is an error response.
+type apiError struct {
+	status int
+	code   string
+	msg    string
+}
+
+func (e *apiError) Error() string { return e.msg }
+
+func invalidParam(format string, args ...interface{}) *apiError {
+	return &apiError{http.StatusConflict, "409", fmt.Sprintf(format, args...)}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := s.handle(r)
+	if err != nil {
+		var e *apiError
+		if !errors.As(err, &e) {
+			e = &apiError{http.StatusInternalServerError, "500", err.Error()}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(e.status)
+		if _, err := strconv.Atoi(e.code); err == nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{"code": e.status, "status": e.msg})
+		} else {
+			json.NewEncoder(w).Encode(map[string]interface{}{"code": e.code, "message": e.msg})
+		}
+		return
+	}
+	etag := fmt.Sprintf("%x", sha1.Sum(body))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag)
+	w.Write(body)
+}
+
+// validKinds lists the sub-collections available for each collection.
+var validKinds = map[string][]string{
+	"characters": {"comics", "events", "series", "stories"},
+	"comics":     {"characters", "creators", "events", "stories"},
+	"creators":   {"comics", "events", "series", "stories"},
+	"events":     {"characters", "comics", "creators", "series", "stories"},
+	"series":     {"characters", "comics", "creators", "events", "stories"},
+	"stories":    {"characters", "comics", "creators", "events", "series"},
+}
+
+func (s *Server) handle(r *http.Request) ([]byte, error) {
+	if r.Method != http.MethodGet {
+		return nil, &apiError{http.StatusMethodNotAllowed, "405", "Method not allowed."}
+	}
+	if err := s.authenticate(r); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(r.URL.Path, basePath+"/") {
+		return nil, &apiError{http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("%s does not exist", r.URL.Path)}
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, basePath+"/"), "/")
+	kind := parts[0]
+	if _, ok := validKinds[kind]; !ok || len(parts) > 3 {
+		return nil, &apiError{http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("%s does not exist", r.URL.Path)}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var candidates []ref
+	switch len(parts) {
+	case 1:
+		for e := range s.entities {
+			if e.kind == kind {
+				candidates = append(candidates, e)
+			}
+		}
+	case 2, 3:
+		id, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, &apiError{http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("%s does not exist", r.URL.Path)}
+		}
+		parent := ref{kind, id}
+		if _, ok := s.entities[parent]; !ok {
+			return nil, &apiError{http.StatusNotFound, "404", fmt.Sprintf("We couldn't find that %s", strings.TrimSuffix(kind, "s"))}
+		}
+		if len(parts) == 2 {
+			if len(r.URL.Query()) > 3 {
+				return nil, invalidParam("Single resource requests do not accept parameters.")
+			}
+			return s.respond(0, 1, 1, []ref{parent})
+		}
+		sub := parts[2]
+		if !contains(validKinds[kind], sub) {
+			return nil, &apiError{http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("%s does not exist", r.URL.Path)}
+		}
+		for l := range s.links[parent] {
+			if l.kind == sub {
+				if _, ok := s.entities[l]; ok {
+					candidates = append(candidates, l)
+				}
+			}
+		}
+		kind = sub
+	}
+	return s.search(kind, r.URL.Query(), candidates)
+}
+
+// authenticate verifies the request's signature.
+//
+// See http://developer.marvel.com/documentation/authorization
+func (s *Server) authenticate(r *http.Request) error {
+	q := r.URL.Query()
+	apikey, ts, hash := q.Get("apikey"), q.Get("ts"), q.Get("hash")
+	switch {
+	case apikey == "":
+		return &apiError{http.StatusConflict, "MissingParameter", "You must provide a user key."}
+	case hash == "" && ts == "" && r.Referer() != "":
+		if apikey != s.PublicKey {
+			return &apiError{http.StatusUnauthorized, "InvalidCredentials", "The passed API key is invalid."}
+		}
+		if !s.authorizedReferer(r.Referer()) {
+			return &apiError{http.StatusUnauthorized, "InvalidReferer", "Invalid referer."}
+		}
+		return nil
+	case hash == "":
+		return &apiError{http.StatusConflict, "MissingParameter", "You must provide a hash."}
+	case ts == "":
+		return &apiError{http.StatusConflict, "MissingParameter", "You must provide a timestamp."}
+	case apikey != s.PublicKey:
+		return &apiError{http.StatusUnauthorized, "InvalidCredentials", "The passed API key is invalid."}
+	}
+	h := md5.New()
+	io.WriteString(h, ts+s.PrivateKey+s.PublicKey)
+	if hash != fmt.Sprintf("%x", h.Sum(nil)) {
+		return &apiError{http.StatusUnauthorized, "InvalidCredentials", "That hash, timestamp and key combination is invalid."}
+	}
+	return nil
+}
+
+// authorizedReferer reports whether referer's host is one of s.Referers.
+func (s *Server) authorizedReferer(referer string) bool {
+	u, err := url.Parse(referer)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	for _, r := range s.Referers {
+		if r == host || (strings.HasPrefix(r, "*.") && strings.HasSuffix(host, r[1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+// search filters, orders and paginates candidate entities of a kind.
+func (s *Server) search(kind string, q url.Values, candidates []ref) ([]byte, error) {
+	offset, limit := 0, defaultLimit
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, invalidParam("You must pass a valid offset.")
+		}
+		offset = n
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, invalidParam("You must pass an integer limit greater than 0.")
+		}
+		if n > maxLimit {
+			return nil, invalidParam("You may not request more than %d items.", maxLimit)
+		}
+		limit = n
+	}
+
+	c := collections[kind]
+	var entries []entry
+	for _, r := range candidates {
+		entries = append(entries, s.describe(r))
+	}
+	for name, vals := range q {
+		if name == "apikey" || name == "ts" || name == "hash" || name == "offset" || name == "limit" {
+			continue
+		}
+		v := strings.Join(vals, ",")
+		f, err := c.filter(s, name, v)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			continue
+		}
+		var kept []entry
+		for _, e := range entries {
+			if f(e) {
+				kept = append(kept, e)
+			}
+		}
+		entries = kept
+	}
+	if err := c.sort(entries, q.Get("orderBy")); err != nil {
+		return nil, err
+	}
+
+	total := len(entries)
+	var page []ref
+	for i := offset; i < offset+limit && i < total; i++ {
+		page = append(page, entries[i].ref)
+	}
+	return s.respond(offset, limit, total, page)
+}
+
+func (s *Server) respond(offset, limit, total int, refs []ref) ([]byte, error) {
+	results := []interface{}{}
+	for _, r := range refs {
+		results = append(results, s.render(r))
+	}
+	return json.Marshal(map[string]interface{}{
+		"code":            200,
+		"status":          "Ok",
+		"copyright":       "© 2014 MARVEL",
+		"attributionText": "Data provided by Marvel. © 2014 MARVEL",
+		"data": map[string]interface{}{
+			"offset":  offset,
+			"limit":   limit,
+			"total":   total,
+			"count":   len(results),
+			"results": results,
+		},
+	})
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDate(d *marvel.Date) time.Time {
+	if d == nil {
+		return time.Time{}
+	}
+	return d.Time
+}
+
+// parseTime parses a date parameter, which may be a date or a full timestamp.
+func parseTime(v string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", "2006-01-02T15:04:05-0700", time.RFC3339} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func itoa(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func sortRefs(refs []ref) {
+	sort.Slice(refs, func(i, j int) bool { return refs[i].id < refs[j].id })
+}
@@ -0,0 +1,236 @@
+package marveltest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	marvel "github.com/imjasonh/go-marvel"
+)
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
+
+func date(s string) *marvel.Date {
+	d, err := marvel.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+func newServer(t *testing.T) *Server {
+	s := NewServer()
+	t.Cleanup(s.Close)
+	if err := s.Add(Fixtures{
+		Characters: []marvel.Character{
+			{ID: intPtr(1009351), Name: strPtr("Hulk"), Modified: date("2013-07-15T15:46:51-0400")},
+			{ID: intPtr(1009368), Name: strPtr("Iron Man"), Modified: date("2014-04-29T14:18:17-0400")},
+			{ID: intPtr(1009610), Name: strPtr("Spider-Man"), Modified: date("2014-04-29T14:18:17-0400")},
+		},
+		Events: []marvel.Event{{
+			ID:    intPtr(238),
+			Title: strPtr("Civil War"),
+			Characters: &marvel.CharactersList{Items: []marvel.CharacterSummary{
+				{ResourceURI: strPtr(canonicalURL + "/characters/1009368")},
+				{ResourceURI: strPtr(canonicalURL + "/characters/1009610")},
+			}},
+		}},
+	}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := s.LoadFile("testdata/fixtures.json"); err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	return s
+}
+
+func names(resp *marvel.CharactersResponse) []string {
+	var ns []string
+	for _, c := range resp.Data.Results {
+		ns = append(ns, *c.Name)
+	}
+	return ns
+}
+
+func TestSearch(t *testing.T) {
+	s := newServer(t)
+	c := s.Client()
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		desc   string
+		params marvel.CharactersParams
+		want   []string
+	}{
+		{"all", marvel.CharactersParams{}, []string{"Hulk", "Iron Man", "Spider-Man"}},
+		{"name", marvel.CharactersParams{Name: "hulk"}, []string{"Hulk"}},
+		{"nameStartsWith", marvel.CharactersParams{NameStartsWith: "S"}, []string{"Spider-Man"}},
+		{"events", marvel.CharactersParams{Events: []int{238}}, []string{"Iron Man", "Spider-Man"}},
+		{"comics", marvel.CharactersParams{Comics: []int{6482, 6494}}, []string{"Spider-Man"}},
+		{"orderBy", marvel.CharactersParams{OrderBy: []marvel.CharacterOrder{marvel.CharacterOrderNameDesc}}, []string{"Spider-Man", "Iron Man", "Hulk"}},
+		{"orderBy multiple", marvel.CharactersParams{OrderBy: []marvel.CharacterOrder{marvel.CharacterOrderModified, marvel.CharacterOrderNameDesc}}, []string{"Hulk", "Spider-Man", "Iron Man"}},
+		{"modifiedSince", marvel.CharactersParams{CommonParams: marvel.CommonParams{ModifiedSince: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)}}, []string{"Iron Man", "Spider-Man"}},
+		{"offset and limit", marvel.CharactersParams{CommonParams: marvel.CommonParams{Offset: 1, Limit: 1}}, []string{"Iron Man"}},
+	} {
+		resp, err := c.CharactersContext(ctx, tc.params)
+		if err != nil {
+			t.Errorf("%s: %v", tc.desc, err)
+			continue
+		}
+		if got := names(resp); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: got %q, want %q", tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestSubCollections(t *testing.T) {
+	s := newServer(t)
+	c := s.Client()
+	ctx := context.Background()
+
+	resp, err := c.Event(238).CharactersContext(ctx, marvel.CharactersParams{})
+	if err != nil {
+		t.Fatalf("CharactersContext: %v", err)
+	}
+	if got, want := names(resp), []string{"Iron Man", "Spider-Man"}; !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Links are followed in both directions.
+	spidey, err := c.Character(1009610).GetContext(ctx)
+	if err != nil {
+		t.Fatalf("GetContext: %v", err)
+	}
+	ch := spidey.Data.Results[0]
+	if *ch.Events.Available != 1 || *ch.Comics.Available != 2 {
+		t.Errorf("got %d events and %d comics, want 1 and 2", *ch.Events.Available, *ch.Comics.Available)
+	}
+	summary := ch.Events.Items[0]
+	if id, err := summary.ID(); err != nil || id != 238 {
+		t.Errorf("ID() = %d, %v, want 238", id, err)
+	}
+	if *summary.Name != "Civil War" {
+		t.Errorf("summary name = %q, want Civil War", *summary.Name)
+	}
+	event, err := summary.Fetch(ctx, c)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if event.Characters == nil || *event.Characters.Available != 2 {
+		t.Errorf("fetched event %+v, want complete Civil War", event)
+	}
+	events, err := ch.Events.Expand(ctx, c, marvel.EventsParams{})
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	if len(events) != 1 || *events[0].Title != "Civil War" {
+		t.Errorf("got events %+v, want Civil War", events)
+	}
+
+	var titles []string
+	for comic, err := range c.SingleSeries(1987).AllComics(ctx, marvel.ComicsParams{
+		OrderBy: []marvel.ComicOrder{marvel.ComicOrderIssueNumberDesc},
+	}, marvel.PageOptions{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("AllComics: %v", err)
+		}
+		titles = append(titles, *comic.Title)
+	}
+	if want := []string{"Amazing Spider-Man (1963) #3", "Amazing Spider-Man (1963) #2", "Amazing Spider-Man (1963) #1"}; !slices.Equal(titles, want) {
+		t.Errorf("got %q, want %q", titles, want)
+	}
+
+	comics, err := c.ComicsContext(ctx, marvel.ComicsParams{DateRange: marvel.DateRange{
+		Start: time.Date(1963, 4, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(1963, 12, 31, 0, 0, 0, 0, time.UTC),
+	}})
+	if err != nil {
+		t.Fatalf("ComicsContext: %v", err)
+	}
+	if n := len(comics.Data.Results); n != 2 {
+		t.Errorf("got %d comics in date range, want 2", n)
+	}
+
+	if _, err := c.Character(1).GetContext(ctx); !errors.Is(err, marvel.ErrNotFound) {
+		t.Errorf("got error %v, want %v", err, marvel.ErrNotFound)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	c := s.Client()
+	c.PrivateKey = "wrong"
+	if _, err := c.CharactersContext(ctx, marvel.CharactersParams{}); !errors.Is(err, marvel.ErrInvalidCredentials) {
+		t.Errorf("got error %v, want %v", err, marvel.ErrInvalidCredentials)
+	}
+
+	c = s.Client()
+	for _, p := range []marvel.CharactersParams{
+		{CommonParams: marvel.CommonParams{Limit: 101}},
+		{OrderBy: []marvel.CharacterOrder{"title"}},
+		{Comics: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+	} {
+		if _, err := c.CharactersContext(ctx, p); !errors.Is(err, marvel.ErrInvalidParameter) {
+			t.Errorf("%+v: got error %v, want %v", p, err, marvel.ErrInvalidParameter)
+		}
+	}
+}
+
+func TestReferer(t *testing.T) {
+	s := newServer(t)
+	s.Referers = []string{"*.example.com"}
+	ctx := context.Background()
+
+	c := s.Client()
+	c.PrivateKey = ""
+	c.Referer = "https://tools.example.com/comics"
+	resp, err := c.CharactersContext(ctx, marvel.CharactersParams{Name: "Hulk"})
+	if err != nil {
+		t.Fatalf("CharactersContext: %v", err)
+	}
+	if got := names(resp); !slices.Equal(got, []string{"Hulk"}) {
+		t.Errorf("got %q, want Hulk", got)
+	}
+
+	c.Referer = "https://example.org/"
+	if _, err := c.CharactersContext(ctx, marvel.CharactersParams{}); !errors.Is(err, marvel.ErrInvalidCredentials) {
+		t.Errorf("got error %v, want %v", err, marvel.ErrInvalidCredentials)
+	}
+	c.Referer = ""
+	if _, err := c.CharactersContext(ctx, marvel.CharactersParams{}); !errors.Is(err, marvel.ErrInvalidParameter) {
+		t.Errorf("got error %v, want %v", err, marvel.ErrInvalidParameter)
+	}
+}
+
+func TestETag(t *testing.T) {
+	s := newServer(t)
+	c := s.Client()
+	c.ETags = &marvel.MemoryETagStore{}
+	ctx := context.Background()
+	for i, want := range []bool{false, true} {
+		resp, err := c.Character(1009351).GetContext(ctx)
+		if err != nil {
+			t.Fatalf("GetContext: %v", err)
+		}
+		if resp.NotModified != want {
+			t.Errorf("request %d: NotModified = %t, want %t", i, resp.NotModified, want)
+		}
+	}
+}
+
+func TestDateDescriptor(t *testing.T) {
+	s := newServer(t)
+	s.Now = func() time.Time { return time.Date(1963, 5, 14, 0, 0, 0, 0, time.UTC) }
+	resp, err := s.Client().ComicsContext(context.Background(), marvel.ComicsParams{DateDescriptor: marvel.LastWeek})
+	if err != nil {
+		t.Fatalf("ComicsContext: %v", err)
+	}
+	if len(resp.Data.Results) != 1 || *resp.Data.Results[0].Title != "Amazing Spider-Man (1963) #2" {
+		t.Errorf("got %d comics, want #2", len(resp.Data.Results))
+	}
+}
@@ -0,0 +1,213 @@
+package marvel
+
+import (
+	"context"
+	"iter"
+)
+
+// maxLimit is the largest page size the API allows.
+const maxLimit = 100
+
+// PageOptions controls how an iterator pages through results.
+type PageOptions struct {
+	// PageSize is the number of results to request per page, up to 100.
+	// Defaults to the Limit in the request parameters, or 100.
+	PageSize int
+	// MaxItems, if positive, is the maximum number of results to yield.
+	MaxItems int
+	// Concurrency, if greater than 1, is the number of pages to fetch
+	// concurrently once the first page reports the total number of results.
+	// Results are still yielded in order.
+	Concurrency int
+}
+
+// pageFunc fetches the page of results starting at offset.
+type pageFunc[T any] func(ctx context.Context, offset, limit int) ([]T, CommonList, error)
+
+// paginate returns an iterator over the results of successive pages fetched
+// by fetch, starting at common's Offset.
+//
+// Iteration stops after the page containing the last result reported by the
+// API's total, after an empty page, or after opts.MaxItems results. If a page
+// cannot be fetched, the error is yielded with the zero T and iteration stops.
+//
+// If opts.Concurrency is greater than 1, the pages after the first are
+// fetched concurrently by prefetch.
+func paginate[T any](ctx context.Context, common CommonParams, opts PageOptions, fetch pageFunc[T]) iter.Seq2[T, error] {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = common.Limit
+	}
+	if limit <= 0 || limit > maxLimit {
+		limit = maxLimit
+	}
+	return func(yield func(T, error) bool) {
+		offset, n, limit := common.Offset, 0, limit
+		for {
+			if opts.MaxItems > 0 && opts.MaxItems-n < limit {
+				limit = opts.MaxItems - n
+			}
+			results, list, err := fetch(ctx, offset, limit)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, r := range results {
+				if !yield(r, nil) {
+					return
+				}
+				n++
+			}
+			offset += len(results)
+			if len(results) == 0 ||
+				(opts.MaxItems > 0 && n >= opts.MaxItems) ||
+				(list.Total != nil && offset >= *list.Total) {
+				return
+			}
+			if opts.Concurrency > 1 && list.Total != nil {
+				end := *list.Total
+				if opts.MaxItems > 0 && offset+opts.MaxItems-n < end {
+					end = offset + opts.MaxItems - n
+				}
+				prefetch(ctx, fetch, offset, end, limit, opts.Concurrency, yield)
+				return
+			}
+		}
+	}
+}
+
+// pageParams is implemented by pointers to request parameters, which embed
+// CommonParams.
+type pageParams[P any] interface {
+	*P
+	page() *CommonParams
+}
+
+func (p *CommonParams) page() *CommonParams { return p }
+
+// listAll returns an iterator over the entities of type T at path matching
+// params, fetching pages as needed.
+func listAll[T any, P any, PP pageParams[P]](ctx context.Context, c Client, path string, params P, opts PageOptions) iter.Seq2[T, error] {
+	return paginate(ctx, *PP(&params).page(), opts, func(ctx context.Context, offset, limit int) ([]T, CommonList, error) {
+		p := params
+		common := PP(&p).page()
+		common.Offset, common.Limit = offset, limit
+		resp, err := get[T](ctx, c, path, p)
+		if err != nil {
+			return nil, CommonList{}, err
+		}
+		return resp.Data.Results, resp.Data.CommonList, nil
+	})
+}
+
+// fail returns an iterator that yields only err.
+func fail[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+// prefetch yields the results of the pages between offsets start and end,
+// fetching up to concurrency pages ahead of those being yielded. Like
+// paginate, it stops after an empty page.
+//
+// If ctx carries a RawResponse, each page's response is captured separately
+// and stored in it just before that page's results are yielded.
+func prefetch[T any](ctx context.Context, fetch pageFunc[T], start, end, limit, concurrency int, yield func(T, error) bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	raw := rawResponse(ctx)
+
+	type page struct {
+		results []T
+		raw     *RawResponse
+		err     error
+		// last is set if the API returned no more results for the page.
+		last bool
+	}
+	var pages []chan page
+	for off := start; off < end; off += limit {
+		pages = append(pages, make(chan page, 1))
+	}
+	sem := make(chan struct{}, concurrency)
+	go func() {
+		for i, ch := range pages {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			off := start + i*limit
+			l := limit
+			if end-off < l {
+				l = end - off
+			}
+			go func() {
+				ctx := ctx
+				var pageRaw *RawResponse
+				if raw != nil {
+					pageRaw = new(RawResponse)
+					ctx = WithRawResponse(ctx, pageRaw)
+				}
+				// The API may return fewer results than requested, so
+				// fetch the rest of the page until it is full or empty.
+				var p page
+				for len(p.results) < l {
+					results, _, err := fetch(ctx, off+len(p.results), l-len(p.results))
+					if err != nil {
+						p.err = err
+						break
+					}
+					if len(results) == 0 {
+						p.last = true
+						break
+					}
+					p.results = append(p.results, results...)
+				}
+				p.raw = pageRaw
+				ch <- p
+			}()
+		}
+	}()
+
+	var zero T
+	for _, ch := range pages {
+		var p page
+		select {
+		case p = <-ch:
+		case <-ctx.Done():
+			yield(zero, ctx.Err())
+			return
+		}
+		<-sem
+		if raw != nil {
+			*raw = *p.raw
+		}
+		if p.err != nil {
+			yield(zero, p.err)
+			return
+		}
+		for _, r := range p.results {
+			if !yield(r, nil) {
+				return
+			}
+		}
+		if p.last {
+			return
+		}
+	}
+}
+
+// collect returns every value yielded by seq, or the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var all []T
+	for v, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, v)
+	}
+	return all, nil
+}
@@ -0,0 +1,310 @@
+package marvel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// requestLog records the offset and limit of each request.
+type requestLog struct {
+	mu   sync.Mutex
+	reqs []string
+}
+
+func (l *requestLog) requests() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.reqs...)
+}
+
+// pagedHandler serves total comics, with digital IDs from 0, honoring offset
+// and limit. If maxPage is positive, at most maxPage comics are returned per
+// page, whatever the limit.
+func pagedHandler(t *testing.T, total, maxPage int, log *requestLog) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		log.mu.Lock()
+		log.reqs = append(log.reqs, q.Get("offset")+"/"+q.Get("limit"))
+		log.mu.Unlock()
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		n := limit
+		if maxPage > 0 && n > maxPage {
+			n = maxPage
+		}
+		var resp ComicsResponse
+		for i := offset; i < offset+n && i < total; i++ {
+			id := i
+			resp.Data.Results = append(resp.Data.Results, Comic{DigitalID: &id})
+		}
+		count := len(resp.Data.Results)
+		resp.Data.CommonList = CommonList{Offset: &offset, Limit: &limit, Total: &total, Count: &count}
+		// Writes fail if the iterator stopped early and canceled the request.
+		if err := json.NewEncoder(w).Encode(resp); err != nil && r.Context().Err() == nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestAllComics(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		total        int
+		params       ComicsParams
+		opts         PageOptions
+		wantN        int
+		wantRequests []string
+	}{{
+		desc:         "default page size",
+		total:        250,
+		wantN:        250,
+		wantRequests: []string{"/100", "100/100", "200/100"},
+	}, {
+		desc:         "page size from params",
+		total:        25,
+		params:       ComicsParams{CommonParams: CommonParams{Limit: 10}},
+		wantN:        25,
+		wantRequests: []string{"/10", "10/10", "20/10"},
+	}, {
+		desc:         "exact multiple of page size",
+		total:        20,
+		opts:         PageOptions{PageSize: 10},
+		wantN:        20,
+		wantRequests: []string{"/10", "10/10"},
+	}, {
+		desc:         "max items",
+		total:        250,
+		opts:         PageOptions{PageSize: 20, MaxItems: 30},
+		wantN:        30,
+		wantRequests: []string{"/20", "20/10"},
+	}, {
+		desc:         "starting offset",
+		total:        25,
+		params:       ComicsParams{CommonParams: CommonParams{Offset: 15}},
+		wantN:        10,
+		wantRequests: []string{"15/100"},
+	}, {
+		desc:         "empty",
+		total:        0,
+		wantRequests: []string{"/100"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var log requestLog
+			c := testClient(t, pagedHandler(t, tc.total, 0, &log))
+			n := 0
+			for comic, err := range c.SingleSeries(1987).AllComics(context.Background(), tc.params, tc.opts) {
+				if err != nil {
+					t.Fatalf("AllComics: %v", err)
+				}
+				if want := tc.params.Offset + n; *comic.DigitalID != want {
+					t.Errorf("got comic %d, want %d", *comic.DigitalID, want)
+				}
+				n++
+			}
+			if n != tc.wantN {
+				t.Errorf("got %d comics, want %d", n, tc.wantN)
+			}
+			requests := log.requests()
+			if len(requests) != len(tc.wantRequests) {
+				t.Fatalf("got requests %q, want %q", requests, tc.wantRequests)
+			}
+			for i := range requests {
+				if requests[i] != tc.wantRequests[i] {
+					t.Errorf("got requests %q, want %q", requests, tc.wantRequests)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestAllComicsRepeated(t *testing.T) {
+	var log requestLog
+	c := testClient(t, pagedHandler(t, 250, 0, &log))
+	seq := c.AllComics(context.Background(), ComicsParams{}, PageOptions{PageSize: 20, MaxItems: 30})
+	for range 2 {
+		n := 0
+		for _, err := range seq {
+			if err != nil {
+				t.Fatalf("AllComics: %v", err)
+			}
+			n++
+		}
+		if n != 30 {
+			t.Errorf("got %d comics, want 30", n)
+		}
+	}
+	if got, want := log.requests(), []string{"/20", "20/10", "/20", "20/10"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got requests %v, want %v", got, want)
+	}
+}
+
+func TestAllComicsBreak(t *testing.T) {
+	var log requestLog
+	c := testClient(t, pagedHandler(t, 250, 0, &log))
+	for _, err := range c.AllComics(context.Background(), ComicsParams{}, PageOptions{}) {
+		if err != nil {
+			t.Fatalf("AllComics: %v", err)
+		}
+		break
+	}
+	if n := len(log.requests()); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestAllComicsError(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	n := 0
+	for _, err := range c.AllComics(context.Background(), ComicsParams{}, PageOptions{}) {
+		n++
+		if err == nil {
+			t.Errorf("got nil error")
+		}
+	}
+	if n != 1 {
+		t.Errorf("got %d values, want 1", n)
+	}
+}
+
+func TestAllComicsConcurrent(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		total        int
+		maxPage      int
+		opts         PageOptions
+		wantN        int
+		wantRequests []string
+	}{{
+		desc:         "all",
+		total:        950,
+		opts:         PageOptions{PageSize: 100, Concurrency: 4},
+		wantN:        950,
+		wantRequests: []string{"/100", "100/100", "200/100", "300/100", "400/100", "500/100", "600/100", "700/100", "800/100", "900/50"},
+	}, {
+		desc:         "max items",
+		total:        950,
+		opts:         PageOptions{PageSize: 100, MaxItems: 250, Concurrency: 4},
+		wantN:        250,
+		wantRequests: []string{"/100", "100/100", "200/50"},
+	}, {
+		desc:         "short pages",
+		total:        250,
+		maxPage:      50,
+		opts:         PageOptions{PageSize: 100, Concurrency: 4},
+		wantN:        250,
+		wantRequests: []string{"/100", "50/100", "100/50", "150/100", "200/50"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var log requestLog
+			c := testClient(t, pagedHandler(t, tc.total, tc.maxPage, &log))
+			c.Limiter = NewTokenBucket(1000, 4)
+			n := 0
+			for comic, err := range c.AllComics(context.Background(), ComicsParams{}, tc.opts) {
+				if err != nil {
+					t.Fatalf("AllComics: %v", err)
+				}
+				if *comic.DigitalID != n {
+					t.Fatalf("got comic %d, want %d", *comic.DigitalID, n)
+				}
+				n++
+			}
+			if n != tc.wantN {
+				t.Errorf("got %d comics, want %d", n, tc.wantN)
+			}
+			requests := log.requests()
+			sort.Slice(requests, func(i, j int) bool {
+				a, _ := strconv.Atoi(strings.Split(requests[i], "/")[0])
+				b, _ := strconv.Atoi(strings.Split(requests[j], "/")[0])
+				return a < b
+			})
+			if !reflect.DeepEqual(requests, tc.wantRequests) {
+				t.Errorf("got requests %q, want %q", requests, tc.wantRequests)
+			}
+		})
+	}
+}
+
+func TestAllComicsConcurrentBreak(t *testing.T) {
+	var log requestLog
+	c := testClient(t, pagedHandler(t, 10000, 0, &log))
+	n := 0
+	for _, err := range c.AllComics(context.Background(), ComicsParams{}, PageOptions{Concurrency: 3}) {
+		if err != nil {
+			t.Fatalf("AllComics: %v", err)
+		}
+		if n++; n == 150 {
+			break
+		}
+	}
+	// The first page, and no more than the prefetch window after the second.
+	if n := len(log.requests()); n > 5 {
+		t.Errorf("got %d requests, want at most 5", n)
+	}
+}
+
+func TestListExpand(t *testing.T) {
+	const total = 45
+	var paths []string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		paths = append(paths, r.URL.Path+"?"+q.Get("orderBy")+"&"+q.Get("offset"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		var resp CharactersResponse
+		for i := offset; i < offset+limit && i < total; i++ {
+			id := i
+			resp.Data.Results = append(resp.Data.Results, Character{ID: &id})
+		}
+		t := total
+		resp.Data.Total = &t
+		json.NewEncoder(w).Encode(resp)
+	}))
+
+	available, returned := total, 20
+	l := CharactersList{ResourceList: ResourceList{
+		Available:     &available,
+		Returned:      &returned,
+		CollectionURI: strPtr("http://gateway.marvel.com/v1/public/comics/21366/characters"),
+	}}
+	if !l.Truncated() {
+		t.Errorf("Truncated() = false, want true")
+	}
+	chars, err := l.Expand(context.Background(), c, CharactersParams{
+		CommonParams: CommonParams{Limit: 20},
+		OrderBy:      []CharacterOrder{CharacterOrderName},
+	})
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	if len(chars) != total {
+		t.Errorf("got %d characters, want %d", len(chars), total)
+	}
+	want := []string{
+		"/v1/public/comics/21366/characters?name&",
+		"/v1/public/comics/21366/characters?name&20",
+		"/v1/public/comics/21366/characters?name&40",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got requests %q, want %q", paths, want)
+	}
+}
+
+func TestListExpandForeignURI(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	l := StoriesList{ResourceList: ResourceList{CollectionURI: strPtr("http://example.com/v1/public/comics/1/stories")}}
+	if _, err := l.Expand(context.Background(), c, StoriesParams{}); err == nil {
+		t.Errorf("Expand with foreign collection URI succeeded")
+	}
+}
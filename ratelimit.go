@@ -0,0 +1,234 @@
+package marvel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExhausted is returned when a DailyBudget has no calls remaining and
+// is not configured to block.
+var ErrQuotaExhausted = errors.New("marvel: daily call quota exhausted")
+
+// Limiter controls the rate at which a Client issues requests.
+//
+// Wait is called before every attempt, including retries, and must block
+// until the request may proceed or return an error if it may not.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Limiters returns a Limiter that waits on each of ls in turn.
+func Limiters(ls ...Limiter) Limiter {
+	return multiLimiter(ls)
+}
+
+type multiLimiter []Limiter
+
+func (m multiLimiter) Wait(ctx context.Context) error {
+	for _, l := range m {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TokenBucket is a Limiter that paces requests to a steady rate, allowing
+// short bursts.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a TokenBucket that allows rate requests per second,
+// with bursts of up to burst requests. It panics if rate is not positive.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if !(rate > 0) {
+		panic(fmt.Sprintf("marvel: non-positive rate %v for NewTokenBucket", rate))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// Wait blocks until a token is available, or until ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+	b.tokens--
+	var d time.Duration
+	if b.tokens < 0 {
+		d = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if d == 0 {
+		return nil
+	}
+	if err := sleep(ctx, d); err != nil {
+		// Return the reserved token.
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// QuotaStats describes the state of a DailyBudget.
+type QuotaStats struct {
+	Limit     int
+	Used      int
+	Remaining int
+	// Resets is when the budget next resets.
+	Resets time.Time
+}
+
+// DailyBudget is a Limiter that counts requests against a daily call quota,
+// optionally persisting the count to a file so that it survives restarts.
+//
+// The persisted count is not coordinated between processes; processes
+// sharing a key should not share a file concurrently.
+type DailyBudget struct {
+	// Block causes Wait to block until the budget resets when it is
+	// exhausted, instead of returning ErrQuotaExhausted.
+	Block bool
+	// Location determines when a day begins. Defaults to UTC.
+	Location *time.Location
+
+	mu    sync.Mutex
+	limit int
+	path  string
+	day   string
+	used  int
+}
+
+// budgetFile is the persisted state of a DailyBudget.
+type budgetFile struct {
+	Day  string `json:"day"`
+	Used int    `json:"used"`
+}
+
+// NewDailyBudget returns a DailyBudget that allows limit requests per day.
+//
+// If path is not empty, the count is loaded from and saved to that file.
+func NewDailyBudget(limit int, path string) (*DailyBudget, error) {
+	b := &DailyBudget{limit: limit, path: path}
+	if path == "" {
+		return b, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var f budgetFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("marvel: reading daily budget %s: %v", path, err)
+	}
+	b.day, b.used = f.Day, f.Used
+	return b, nil
+}
+
+// Wait counts a request against the budget. If the budget is exhausted it
+// returns ErrQuotaExhausted, or if Block is set, waits until the budget
+// resets or ctx is done.
+func (b *DailyBudget) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now().In(b.location())
+		b.reset(now)
+		if b.used < b.limit {
+			b.used++
+			err := b.save()
+			b.mu.Unlock()
+			return err
+		}
+		b.mu.Unlock()
+
+		if !b.Block {
+			return ErrQuotaExhausted
+		}
+		if err := sleep(ctx, nextDay(now).Sub(now)); err != nil {
+			return err
+		}
+	}
+}
+
+// Stats returns the current state of the budget.
+func (b *DailyBudget) Stats() QuotaStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now().In(b.location())
+	b.reset(now)
+	return QuotaStats{
+		Limit:     b.limit,
+		Used:      b.used,
+		Remaining: b.limit - b.used,
+		Resets:    nextDay(now),
+	}
+}
+
+func (b *DailyBudget) location() *time.Location {
+	if b.Location == nil {
+		return time.UTC
+	}
+	return b.Location
+}
+
+// reset clears the count if now is in a different day than the count.
+func (b *DailyBudget) reset(now time.Time) {
+	if day := now.Format("2006-01-02"); day != b.day {
+		b.day, b.used = day, 0
+	}
+}
+
+// save writes the count to the budget's file, if any.
+func (b *DailyBudget) save() error {
+	if b.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(budgetFile{Day: b.day, Used: b.used})
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(b.path), filepath.Base(b.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), b.path)
+}
+
+// nextDay returns the start of the day after t, in t's location.
+func nextDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+}
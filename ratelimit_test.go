@@ -0,0 +1,96 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(100, 2)
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := b.Wait(ctx); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	// Two requests are allowed immediately, and two more at 10ms apart.
+	if d := time.Since(start); d < 15*time.Millisecond {
+		t.Errorf("4 requests took %v, want at least 15ms", d)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	b = NewTokenBucket(0.001, 1)
+	b.Wait(ctx)
+	if err := b.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestTokenBucketInvalidRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewTokenBucket(%v, 1) did not panic", rate)
+				}
+			}()
+			NewTokenBucket(rate, 1)
+		}()
+	}
+}
+
+func TestDailyBudget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budget.json")
+	b, err := NewDailyBudget(3, path)
+	if err != nil {
+		t.Fatalf("NewDailyBudget: %v", err)
+	}
+
+	var calls int32
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"code":200}`))
+	}))
+	c.Limiter = Limiters(NewTokenBucket(1000, 10), b)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := c.CharactersContext(ctx, CharactersParams{}); err != nil {
+			t.Fatalf("CharactersContext: %v", err)
+		}
+	}
+	if got := b.Stats(); got.Used != 2 || got.Remaining != 1 || !got.Resets.After(time.Now()) {
+		t.Errorf("Stats() = %+v, want 2 used and 1 remaining", got)
+	}
+
+	// The count survives reloading from the file.
+	b, err = NewDailyBudget(3, path)
+	if err != nil {
+		t.Fatalf("NewDailyBudget: %v", err)
+	}
+	c.Limiter = b
+	if _, err := c.CharactersContext(ctx, CharactersParams{}); err != nil {
+		t.Fatalf("CharactersContext: %v", err)
+	}
+	if _, err := c.CharactersContext(ctx, CharactersParams{}); !errors.Is(err, ErrQuotaExhausted) {
+		t.Errorf("got error %v, want %v", err, ErrQuotaExhausted)
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+
+	b.Block = true
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.CharactersContext(ctx, CharactersParams{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,187 @@
+package marvel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+)
+
+// RawResponse holds a response exactly as it was returned by the API.
+type RawResponse struct {
+	// StatusCode is the HTTP status code of the response, e.g. 304 if the
+	// response was not modified since the one in the Client's ETagStore.
+	StatusCode int
+	// Header holds the response headers.
+	Header http.Header
+	// Body is the response body.
+	Body []byte
+	// Cached reports whether the response was served from the Client's
+	// Cache, in which case no request was made and only Body is set.
+	Cached bool
+}
+
+type rawResponseKey struct{}
+
+// WithRawResponse returns a context that makes requests store their raw
+// response in raw, alongside the typed response. Iterators that fetch several
+// pages store the response of the page whose results are being yielded, even
+// when pages are fetched concurrently.
+//
+// raw is written by the goroutine making the request, or ranging over the
+// iterator, so it must not be shared by requests made concurrently.
+func WithRawResponse(ctx context.Context, raw *RawResponse) context.Context {
+	return context.WithValue(ctx, rawResponseKey{}, raw)
+}
+
+func rawResponse(ctx context.Context) *RawResponse {
+	raw, _ := ctx.Value(rawResponseKey{}).(*RawResponse)
+	return raw
+}
+
+// extraHolder is implemented by entities that keep the fields of their JSON
+// representation that they do not otherwise decode.
+type extraHolder interface {
+	extra() map[string]json.RawMessage
+}
+
+var extraHolderType = reflect.TypeOf((*extraHolder)(nil)).Elem()
+
+// unmarshalExtra decodes b into v, a pointer to a struct type without an
+// UnmarshalJSON method, and returns the fields in b that v has no field for.
+func unmarshalExtra(b []byte, v interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, nil
+	}
+	fields := jsonFields(reflect.TypeOf(v).Elem())
+	var extra map[string]json.RawMessage
+	for k, m := range all {
+		if _, ok := lookupField(fields, k); ok {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[k] = m
+	}
+	return extra, nil
+}
+
+// marshalExtra encodes v, a struct type without a MarshalJSON method, adding
+// the fields in extra.
+func marshalExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+	for k, m := range extra {
+		if _, ok := all[k]; !ok {
+			all[k] = m
+		}
+	}
+	return json.Marshal(all)
+}
+
+func (c Character) extra() map[string]json.RawMessage { return c.Extra }
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in Extra.
+func (c *Character) UnmarshalJSON(b []byte) error {
+	type character Character
+	extra, err := unmarshalExtra(b, (*character)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (c Character) MarshalJSON() ([]byte, error) {
+	type character Character
+	return marshalExtra(character(c), c.Extra)
+}
+
+func (c Comic) extra() map[string]json.RawMessage { return c.Extra }
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in Extra.
+func (c *Comic) UnmarshalJSON(b []byte) error {
+	type comic Comic
+	extra, err := unmarshalExtra(b, (*comic)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (c Comic) MarshalJSON() ([]byte, error) {
+	type comic Comic
+	return marshalExtra(comic(c), c.Extra)
+}
+
+func (c Creator) extra() map[string]json.RawMessage { return c.Extra }
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in Extra.
+func (c *Creator) UnmarshalJSON(b []byte) error {
+	type creator Creator
+	extra, err := unmarshalExtra(b, (*creator)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (c Creator) MarshalJSON() ([]byte, error) {
+	type creator Creator
+	return marshalExtra(creator(c), c.Extra)
+}
+
+func (e Event) extra() map[string]json.RawMessage { return e.Extra }
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in Extra.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	type event Event
+	extra, err := unmarshalExtra(b, (*event)(e))
+	e.Extra = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+	return marshalExtra(event(e), e.Extra)
+}
+
+func (s Series) extra() map[string]json.RawMessage { return s.Extra }
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in Extra.
+func (s *Series) UnmarshalJSON(b []byte) error {
+	type series Series
+	extra, err := unmarshalExtra(b, (*series)(s))
+	s.Extra = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (s Series) MarshalJSON() ([]byte, error) {
+	type series Series
+	return marshalExtra(series(s), s.Extra)
+}
+
+func (s Story) extra() map[string]json.RawMessage { return s.Extra }
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in Extra.
+func (s *Story) UnmarshalJSON(b []byte) error {
+	type story Story
+	extra, err := unmarshalExtra(b, (*story)(s))
+	s.Extra = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, including the fields in Extra.
+func (s Story) MarshalJSON() ([]byte, error) {
+	type story Story
+	return marshalExtra(story(s), s.Extra)
+}
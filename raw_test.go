@@ -0,0 +1,102 @@
+package marvel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRawResponse(t *testing.T) {
+	body := `{"code":200,"data":{"results":[{"id":1,"name":"Hulk"}]}}`
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test", "yes")
+		w.Write([]byte(body))
+	}))
+	c.Cache = NewLRUCache(10, time.Hour)
+
+	var raw RawResponse
+	ctx := WithRawResponse(context.Background(), &raw)
+	resp, err := c.Character(1).GetContext(ctx)
+	if err != nil {
+		t.Fatalf("GetContext: %v", err)
+	}
+	if *resp.Data.Results[0].Name != "Hulk" {
+		t.Errorf("Name = %q, want Hulk", *resp.Data.Results[0].Name)
+	}
+	if raw.StatusCode != http.StatusOK || raw.Header.Get("X-Test") != "yes" || string(raw.Body) != body || raw.Cached {
+		t.Errorf("got raw response %+v", raw)
+	}
+
+	raw = RawResponse{}
+	if _, err := c.Character(1).GetContext(ctx); err != nil {
+		t.Fatalf("GetContext: %v", err)
+	}
+	if !raw.Cached || string(raw.Body) != body {
+		t.Errorf("got raw response %+v, want cached body", raw)
+	}
+}
+
+func TestRawResponseConcurrent(t *testing.T) {
+	var log requestLog
+	c := testClient(t, pagedHandler(t, 95, 0, &log))
+	var raw RawResponse
+	ctx := WithRawResponse(context.Background(), &raw)
+	n := 0
+	for comic, err := range c.AllComics(ctx, ComicsParams{}, PageOptions{PageSize: 10, Concurrency: 4}) {
+		if err != nil {
+			t.Fatalf("AllComics: %v", err)
+		}
+		var page ComicsResponse
+		if err := json.Unmarshal(raw.Body, &page); err != nil {
+			t.Fatalf("decoding raw response: %v", err)
+		}
+		offset, count := *page.Data.Offset, *page.Data.Count
+		if id := *comic.DigitalID; id < offset || id >= offset+count {
+			t.Errorf("comic %d yielded with raw response for offset %d count %d", id, offset, count)
+		}
+		n++
+	}
+	if n != 95 {
+		t.Errorf("got %d comics, want 95", n)
+	}
+}
+
+func TestExtraFields(t *testing.T) {
+	in := `{"id":1,"name":"Hulk","powers":["smash"],"team":{"name":"Avengers"}}`
+	var ch Character
+	if err := json.Unmarshal([]byte(in), &ch); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if *ch.ID != 1 || *ch.Name != "Hulk" {
+		t.Errorf("decoded %+v", ch)
+	}
+	want := map[string]json.RawMessage{
+		"powers": json.RawMessage(`["smash"]`),
+		"team":   json.RawMessage(`{"name":"Avengers"}`),
+	}
+	if !reflect.DeepEqual(ch.Extra, want) {
+		t.Errorf("Extra = %s, want %s", ch.Extra, want)
+	}
+
+	b, err := json.Marshal(ch)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got, wantJSON interface{}
+	json.Unmarshal(b, &got)
+	json.Unmarshal([]byte(in), &wantJSON)
+	if !reflect.DeepEqual(got, wantJSON) {
+		t.Errorf("Marshal = %s, want %s", b, in)
+	}
+
+	var s Story
+	if err := json.Unmarshal([]byte(`{"id":2}`), &s); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if s.Extra != nil {
+		t.Errorf("Extra = %v, want nil", s.Extra)
+	}
+}
@@ -0,0 +1,161 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries failed requests.
+//
+// Each attempt is signed with a fresh timestamp and hash. A nil *RetryPolicy
+// makes a single attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Values less than 1 are treated as 1.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. Defaults to 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including delays requested
+	// by a Retry-After header. Defaults to 30s.
+	MaxBackoff time.Duration
+	// Multiplier is the factor by which the delay grows after each retry.
+	// Defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, of each delay that is
+	// randomized. A Jitter of 0.5 waits between 50% and 100% of the delay.
+	Jitter float64
+
+	// RetryStatus reports whether a response with the given HTTP status code
+	// should be retried. Defaults to DefaultRetryStatus.
+	RetryStatus func(statusCode int) bool
+	// RetryError reports whether a transport error should be retried.
+	// Defaults to DefaultRetryError.
+	RetryError func(err error) bool
+}
+
+// DefaultRetryStatus reports whether statusCode is 429 or one of 500, 502,
+// 503 and 504.
+func DefaultRetryStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// DefaultRetryError reports whether err is a timeout, a reset or refused
+// connection, or a connection closed before the response was complete.
+func DefaultRetryError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// backoff reports whether a request that failed with err on the given attempt
+// should be retried, and if so how long to wait first.
+func (p *RetryPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		retryStatus := p.RetryStatus
+		if retryStatus == nil {
+			retryStatus = DefaultRetryStatus
+		}
+		if !retryStatus(apiErr.StatusCode) {
+			return 0, false
+		}
+		if d, ok := retryAfter(apiErr.Header); ok {
+			if d > maxBackoff {
+				d = maxBackoff
+			}
+			return d, true
+		}
+	} else {
+		retryError := p.RetryError
+		if retryError == nil {
+			retryError = DefaultRetryError
+		}
+		if !retryError(err) {
+			return 0, false
+		}
+	}
+
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 500 * time.Millisecond
+	}
+	mult := p.Multiplier
+	if mult <= 0 {
+		mult = 2
+	}
+	d := float64(initial) * math.Pow(mult, float64(attempt-1))
+	if d > float64(maxBackoff) {
+		d = float64(maxBackoff)
+	}
+	if p.Jitter > 0 {
+		j := math.Min(p.Jitter, 1)
+		d -= d * j * rand.Float64()
+	}
+	return time.Duration(d), true
+}
+
+// retryAfter parses a Retry-After header, which may specify either a number
+// of seconds or an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
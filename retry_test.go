@@ -0,0 +1,114 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	var attempts int32
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("hash") == "" || r.URL.Query().Get("ts") == "" {
+			t.Errorf("attempt is not signed: %s", r.URL)
+		}
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			// Drop the connection without a response.
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		case 3:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"code":200,"data":{"results":[{"id":1009351}]}}`))
+		}
+	}))
+	c.Retry = &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, Jitter: 0.5}
+
+	resp, err := c.CharactersContext(context.Background(), CharactersParams{})
+	if err != nil {
+		t.Fatalf("CharactersContext: %v", err)
+	}
+	if got := *resp.Data.Results[0].ID; got != 1009351 {
+		t.Errorf("got ID %d, want 1009351", got)
+	}
+	if attempts != 4 {
+		t.Errorf("got %d attempts, want 4", attempts)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var attempts int32
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	c.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	_, err := c.CharactersContext(context.Background(), CharactersParams{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("got error %v, want 502 *APIError", err)
+	}
+	if attempts != 3 {
+		t.Errorf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	var attempts int32
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusConflict)
+	}))
+	c.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	if _, err := c.CharactersContext(context.Background(), CharactersParams{}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidParameter)
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestRetryContextDone(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	c.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.CharactersContext(ctx, CharactersParams{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	err := &APIError{StatusCode: http.StatusServiceUnavailable}
+	for attempt, want := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+	} {
+		if got, ok := p.backoff(attempt, err); !ok || got != want {
+			t.Errorf("backoff(%d) = %v, %t; want %v, true", attempt, got, ok, want)
+		}
+	}
+	if _, ok := p.backoff(10, err); ok {
+		t.Errorf("backoff(10) retried after MaxAttempts")
+	}
+
+	err.Header = http.Header{"Retry-After": []string{"3"}}
+	if got, _ := p.backoff(1, err); got != 3*time.Second {
+		t.Errorf("backoff with Retry-After = %v, want 3s", got)
+	}
+}
@@ -0,0 +1,89 @@
+package marvel
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestSchema checks that every field in the golden responses in
+// testdata/schema, which follow the API's published schema, is decoded.
+// Decoded responses are re-encoded, and each JSON path in the golden
+// response must be present in the result. Since entities re-encode the
+// fields they keep in Extra, any field in Extra is reported as not decoded.
+func TestSchema(t *testing.T) {
+	for _, tc := range []struct {
+		file string
+		out  interface{}
+	}{
+		{"characters.json", &CharactersResponse{}},
+		{"comics.json", &ComicsResponse{}},
+		{"creators.json", &CreatorsResponse{}},
+		{"events.json", &EventsResponse{}},
+		{"series.json", &SeriesResponse{}},
+		{"stories.json", &StoriesResponse{}},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			golden, err := os.ReadFile(filepath.Join("testdata", "schema", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(golden, tc.out); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			results := reflect.ValueOf(tc.out).Elem().FieldByName("Data").FieldByName("Results")
+			for i := 0; i < results.Len(); i++ {
+				extra := results.Index(i).FieldByName("Extra").Interface().(map[string]json.RawMessage)
+				for k := range extra {
+					t.Errorf("field data.results[%d].%s was not decoded", i, k)
+				}
+			}
+			decoded, err := json.Marshal(tc.out)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			want, got := jsonPaths(t, golden), jsonPaths(t, decoded)
+			var missing []string
+			for p := range want {
+				if !got[p] {
+					missing = append(missing, p)
+				}
+			}
+			sort.Strings(missing)
+			for _, p := range missing {
+				t.Errorf("field %s was not decoded", p)
+			}
+		})
+	}
+}
+
+// jsonPaths returns the paths of every value in the JSON document b, with
+// array elements denoted by [].
+func jsonPaths(t *testing.T, b []byte) map[string]bool {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	paths := map[string]bool{}
+	var walk func(prefix string, v interface{})
+	walk = func(prefix string, v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, e := range v {
+				p := prefix + "." + k
+				paths[p] = true
+				walk(p, e)
+			}
+		case []interface{}:
+			for _, e := range v {
+				walk(prefix+"[]", e)
+			}
+		}
+	}
+	walk("", v)
+	return paths
+}
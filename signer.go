@@ -0,0 +1,79 @@
+package marvel
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Signer is an http.RoundTripper that authenticates requests to the API by
+// adding the ts, apikey and hash query parameters, replacing any already
+// present. Use it as the Transport of an http.Client to make authenticated
+// requests without a Client.
+//
+// If PrivateKey is empty, requests are authenticated with only the apikey
+// parameter, and the API instead requires a Referer authorized for the key.
+//
+// See http://developer.marvel.com/documentation/authorization
+type Signer struct {
+	PublicKey, PrivateKey string
+
+	// Referer, if not empty, is sent as the Referer header of each request.
+	Referer string
+
+	// Transport makes the signed requests. If nil, http.DefaultTransport is
+	// used.
+	Transport http.RoundTripper
+	// Now returns the time used as the timestamp of each request. If nil,
+	// time.Now is used.
+	Now func() time.Time
+	// Timestamp, if not nil, returns the ts parameter of each request,
+	// instead of the Unix time returned by Now. The API accepts any string
+	// that changes from request to request.
+	Timestamp func() string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (s *Signer) RoundTrip(req *http.Request) (*http.Response, error) {
+	signed := req.Clone(req.Context())
+	q := signed.URL.Query()
+	q.Set("apikey", s.PublicKey)
+	if s.PrivateKey != "" {
+		ts := s.timestamp()
+		q.Set("ts", ts)
+		q.Set("hash", s.Hash(ts))
+	} else {
+		q.Del("ts")
+		q.Del("hash")
+	}
+	signed.URL.RawQuery = q.Encode()
+	if s.Referer != "" {
+		signed.Header.Set("Referer", s.Referer)
+	}
+	t := s.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	return t.RoundTrip(signed)
+}
+
+// Hash returns the hash parameter for a request with the timestamp ts.
+func (s *Signer) Hash(ts string) string {
+	h := md5.New()
+	io.WriteString(h, ts+s.PrivateKey+s.PublicKey)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func (s *Signer) timestamp() string {
+	if s.Timestamp != nil {
+		return s.Timestamp()
+	}
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	return strconv.FormatInt(now().Unix(), 10)
+}
@@ -0,0 +1,126 @@
+package marvel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignerHash(t *testing.T) {
+	// The example from http://developer.marvel.com/documentation/authorization.
+	s := &Signer{PublicKey: "1234", PrivateKey: "abcd"}
+	if got, want := s.Hash("1"), "ffd275c5130566a2916217b101f26150"; got != want {
+		t.Errorf("Hash(1) = %q, want %q", got, want)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+	}))
+	defer srv.Close()
+
+	s := &Signer{
+		PublicKey:  "pub",
+		PrivateKey: "priv",
+		Now:        func() time.Time { return time.Unix(1400000000, 0) },
+	}
+	req, err := http.NewRequest("GET", srv.URL+"/v1/public/characters?name=Hulk&hash=stale", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: s}).Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	want := url.Values{
+		"name":   {"Hulk"},
+		"ts":     {"1400000000"},
+		"apikey": {"pub"},
+		"hash":   {"1c44b469028fee1bbd46bacf9c69d8c6"},
+	}
+	if got.Encode() != want.Encode() {
+		t.Errorf("got query %q, want %q", got.Encode(), want.Encode())
+	}
+	if req.URL.Query().Get("hash") != "stale" {
+		t.Errorf("RoundTrip modified the original request")
+	}
+
+	s.Timestamp = func() string { return "1" }
+	s.PublicKey, s.PrivateKey = "1234", "abcd"
+	req, _ = http.NewRequest("GET", srv.URL, nil)
+	resp, err = (&http.Client{Transport: s}).Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if got.Get("ts") != "1" || got.Get("hash") != "ffd275c5130566a2916217b101f26150" {
+		t.Errorf("got query %q, want ts 1 and the documented hash", got.Encode())
+	}
+}
+
+func TestClientSigner(t *testing.T) {
+	var got url.Values
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Write([]byte(`{"code":200,"data":{"results":[{"id":1}]}}`))
+	}))
+	var used bool
+	c.Client = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		used = true
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	c.Signer = &Signer{
+		PublicKey:  "1234",
+		PrivateKey: "abcd",
+		Timestamp:  func() string { return "1" },
+	}
+	if _, err := c.Character(1).Get(); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := url.Values{
+		"ts":     {"1"},
+		"apikey": {"1234"},
+		"hash":   {"ffd275c5130566a2916217b101f26150"},
+	}
+	if got.Encode() != want.Encode() {
+		t.Errorf("got query %q, want %q", got.Encode(), want.Encode())
+	}
+	if !used {
+		t.Error("request was not made with the Client's Transport")
+	}
+	if c.Signer.Transport != nil {
+		t.Error("Client modified its Signer")
+	}
+}
+
+func TestSignerPublicKeyOnly(t *testing.T) {
+	var got url.Values
+	var referer string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, referer = r.URL.Query(), r.Referer()
+	}))
+	defer srv.Close()
+
+	s := &Signer{PublicKey: "pub", Referer: "https://tools.example.com/"}
+	resp, err := (&http.Client{Transport: s}).Get(srv.URL + "?ts=1&hash=x")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+	if want := (url.Values{"apikey": {"pub"}}); got.Encode() != want.Encode() {
+		t.Errorf("got query %q, want %q", got.Encode(), want.Encode())
+	}
+	if referer != s.Referer {
+		t.Errorf("got Referer %q, want %q", referer, s.Referer)
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper implemented by a function.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
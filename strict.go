@@ -0,0 +1,162 @@
+package marvel
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StrictDecoding configures a Client to check each response for fields that
+// are not captured by the typed structs, for example because the API added a
+// field or a struct tag is wrong.
+//
+// Each response is decoded a second time into a generic value, and every JSON
+// path in it is matched against the fields of the typed struct, so fields kept
+// in an entity's Extra are reported. Fields decoded by other types' own
+// UnmarshalJSON methods are not checked.
+type StrictDecoding struct {
+	// Warn, if not nil, is called for each response that has unknown fields.
+	Warn func(*UnknownFieldsError)
+	// Fail, if true, makes requests whose responses have unknown fields
+	// return an *UnknownFieldsError.
+	Fail bool
+}
+
+// UnknownFieldsError reports fields in a response that were not decoded.
+type UnknownFieldsError struct {
+	// Path is the request path and query, without authentication parameters.
+	Path string
+	// Fields are the JSON paths of the unknown fields, such as
+	// "data.results[].foo", in sorted order.
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("marvel: response for %s has unknown fields: %s", e.Path, strings.Join(e.Fields, ", "))
+}
+
+// check reports the fields in body that were not decoded into out.
+func (s *StrictDecoding) check(path string, body []byte, out interface{}) error {
+	if s == nil || (s.Warn == nil && !s.Fail) {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return err
+	}
+	found := map[string]bool{}
+	unknownFields(reflect.TypeOf(out), v, "", found)
+	if len(found) == 0 {
+		return nil
+	}
+	err := &UnknownFieldsError{Path: path}
+	for f := range found {
+		err.Fields = append(err.Fields, f)
+	}
+	sort.Strings(err.Fields)
+	if s.Warn != nil {
+		s.Warn(err)
+	}
+	if s.Fail {
+		return err
+	}
+	return nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields adds to found the paths of values in v, decoded from JSON
+// at prefix, that have no corresponding field in t.
+func unknownFields(t reflect.Type, v interface{}, prefix string, found map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if pt := reflect.PtrTo(t); pt.Implements(unmarshalerType) && !pt.Implements(extraHolderType) {
+		return
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for k, e := range v {
+				unknownFields(t.Elem(), e, join(prefix, k), found)
+			}
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for k, e := range v {
+				ft, ok := lookupField(fields, k)
+				if !ok {
+					found[join(prefix, k)] = true
+					continue
+				}
+				unknownFields(ft, e, join(prefix, k), found)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, e := range v {
+				unknownFields(t.Elem(), e, prefix+"[]", found)
+			}
+		}
+	}
+}
+
+func join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// jsonFields returns the types of the fields of the struct type t by the
+// names encoding/json decodes them from, including the fields of embedded
+// structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for n, t := range jsonFields(ft) {
+					if _, ok := fields[n]; !ok {
+						fields[n] = t
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// lookupField returns the type of the field that encoding/json decodes key
+// into, preferring an exact match to a case-insensitive one.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for n, t := range fields {
+		if strings.EqualFold(n, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,68 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	body := []byte(`{"code":200,"etag":"x","data":{"total":1,"results":[{
+		"id": 1,
+		"Name": "Hulk",
+		"powers": ["smash"],
+		"comics": {"available": 1, "items": [{"resourceURI": "u", "name": "n", "issue": 1}]},
+		"modified": "2013-07-15T15:46:51-0400"
+	}]},"extra":{"a":1}}`)
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+
+	var warned []*UnknownFieldsError
+	c.Strict = &StrictDecoding{Warn: func(err *UnknownFieldsError) { warned = append(warned, err) }}
+	ctx := context.Background()
+	resp, err := c.Character(1).GetContext(ctx)
+	if err != nil {
+		t.Fatalf("GetContext: %v", err)
+	}
+	if *resp.Data.Results[0].Name != "Hulk" {
+		t.Errorf("Name = %q, want Hulk", *resp.Data.Results[0].Name)
+	}
+	want := []string{"data.results[].comics.items[].issue", "data.results[].powers", "extra"}
+	if len(warned) != 1 || !reflect.DeepEqual(warned[0].Fields, want) {
+		t.Fatalf("warned %v, want fields %q", warned, want)
+	}
+	if warned[0].Path != "/v1/public/characters/1" {
+		t.Errorf("Path = %q", warned[0].Path)
+	}
+
+	c.Strict = &StrictDecoding{Fail: true}
+	_, err = c.Character(1).GetContext(ctx)
+	var uerr *UnknownFieldsError
+	if !errors.As(err, &uerr) || !reflect.DeepEqual(uerr.Fields, want) {
+		t.Errorf("GetContext() = %v, want *UnknownFieldsError", err)
+	}
+}
+
+func TestStrictDecodingSchema(t *testing.T) {
+	for file, out := range map[string]interface{}{
+		"characters.json": &CharactersResponse{},
+		"comics.json":     &ComicsResponse{},
+		"creators.json":   &CreatorsResponse{},
+		"events.json":     &EventsResponse{},
+		"series.json":     &SeriesResponse{},
+		"stories.json":    &StoriesResponse{},
+	} {
+		body, err := os.ReadFile(filepath.Join("testdata", "schema", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := (&StrictDecoding{Fail: true}).check(file, body, out); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+	}
+}
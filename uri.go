@@ -0,0 +1,222 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Kind is a kind of entity, as it appears in resource URIs.
+type Kind string
+
+// Kinds of entities.
+const (
+	KindCharacters Kind = "characters"
+	KindComics     Kind = "comics"
+	KindCreators   Kind = "creators"
+	KindEvents     Kind = "events"
+	KindSeries     Kind = "series"
+	KindStories    Kind = "stories"
+)
+
+func (k Kind) valid() bool {
+	switch k {
+	case KindCharacters, KindComics, KindCreators, KindEvents, KindSeries, KindStories:
+		return true
+	}
+	return false
+}
+
+// kindOf returns the Kind of entities of type T, or "" if T is not an
+// entity type.
+func kindOf[T any]() Kind {
+	var zero T
+	switch any(zero).(type) {
+	case Character:
+		return KindCharacters
+	case Comic:
+		return KindComics
+	case Creator:
+		return KindCreators
+	case Event:
+		return KindEvents
+	case Series:
+		return KindSeries
+	case Story:
+		return KindStories
+	}
+	return ""
+}
+
+// ResourceURI identifies an entity, or a collection of entities related to
+// it, such as http://gateway.marvel.com/v1/public/comics/21366 or
+// http://gateway.marvel.com/v1/public/comics/21366/characters.
+type ResourceURI struct {
+	// Kind is the kind of the entity.
+	Kind Kind
+	// ID is the ID of the entity.
+	ID int
+	// Collection, if not empty, is the kind of the related entities in the
+	// collection.
+	Collection Kind
+}
+
+// ParseResourceURI parses a resource or collection URI returned by the API.
+//
+// The URI must refer to the API's canonical host, using http or https.
+func ParseResourceURI(s string) (ResourceURI, error) {
+	return Client{}.parseResourceURI(s)
+}
+
+// parseResourceURI is like ParseResourceURI, but also accepts URIs relative
+// to the Client's BaseURL.
+func (c Client) parseResourceURI(s string) (ResourceURI, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return ResourceURI{}, fmt.Errorf("marvel: parsing resource URI: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ResourceURI{}, fmt.Errorf("marvel: %q is not a resource URI", s)
+	}
+	for _, b := range []string{basePath, c.BaseURL} {
+		if b == "" {
+			continue
+		}
+		base, err := url.Parse(b)
+		if err != nil {
+			continue
+		}
+		prefix := strings.TrimSuffix(base.Path, "/")
+		if strings.EqualFold(u.Host, base.Host) && strings.HasPrefix(u.Path, prefix+"/") {
+			return parseResourcePath(s, u.Path[len(prefix):])
+		}
+	}
+	return ResourceURI{}, fmt.Errorf("marvel: %q is not a resource URI", s)
+}
+
+// parseResourcePath parses the path of the resource URI s, relative to the
+// base URL.
+func parseResourcePath(s, path string) (ResourceURI, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return ResourceURI{}, fmt.Errorf("marvel: %q is not a resource URI", s)
+	}
+	r := ResourceURI{Kind: Kind(parts[0])}
+	if !r.Kind.valid() {
+		return ResourceURI{}, fmt.Errorf("marvel: %q refers to unknown kind %q", s, parts[0])
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil || id <= 0 {
+		return ResourceURI{}, fmt.Errorf("marvel: %q has invalid ID %q", s, parts[1])
+	}
+	r.ID = id
+	if len(parts) == 3 {
+		r.Collection = Kind(parts[2])
+		if !r.Collection.valid() || r.Collection == r.Kind {
+			return ResourceURI{}, fmt.Errorf("marvel: %q refers to unknown collection %q", s, parts[2])
+		}
+	}
+	return r, nil
+}
+
+// Path returns the path of the URI, relative to the base URL.
+func (r ResourceURI) Path() string {
+	p := fmt.Sprintf("/%s/%d", r.Kind, r.ID)
+	if r.Collection != "" {
+		p += "/" + string(r.Collection)
+	}
+	return p
+}
+
+// String returns the URI as returned by the API.
+func (r ResourceURI) String() string {
+	return basePath + r.Path()
+}
+
+// Entities returns the kind of entities identified by the URI: its Collection
+// if it has one, or else its Kind.
+func (r ResourceURI) Entities() Kind {
+	if r.Collection != "" {
+		return r.Collection
+	}
+	return r.Kind
+}
+
+// resourcePath returns the path, relative to the base URL, of a resource or
+// collection URI returned by the API.
+//
+// URIs returned by the API refer to the canonical host, but URIs relative to
+// the Client's BaseURL are also accepted.
+func (c Client) resourcePath(uri *string) (string, error) {
+	if uri == nil {
+		return "", errors.New("marvel: missing resource URI")
+	}
+	r, err := c.parseResourceURI(*uri)
+	if err != nil {
+		return "", err
+	}
+	return r.Path(), nil
+}
+
+// uriID returns the ID of the entity identified by a resource URI.
+func uriID(uri *string) (int, error) {
+	if uri == nil {
+		return 0, errors.New("marvel: missing resource URI")
+	}
+	r, err := ParseResourceURI(*uri)
+	if err != nil {
+		return 0, err
+	}
+	if r.Collection != "" {
+		return 0, fmt.Errorf("marvel: %q is a collection URI", *uri)
+	}
+	return r.ID, nil
+}
+
+// Resolve issues a request for the resource or collection identified by uri.
+// The response is of the type for the entities the URI identifies, e.g. a
+// *ComicsResponse for a comic or for a series' comics.
+func (c Client) Resolve(ctx context.Context, uri string) (interface{}, error) {
+	r, err := c.parseResourceURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	switch r.Entities() {
+	case KindCharacters:
+		return resolve[Character](ctx, c, r)
+	case KindComics:
+		return resolve[Comic](ctx, c, r)
+	case KindCreators:
+		return resolve[Creator](ctx, c, r)
+	case KindEvents:
+		return resolve[Event](ctx, c, r)
+	case KindSeries:
+		return resolve[Series](ctx, c, r)
+	default:
+		return resolve[Story](ctx, c, r)
+	}
+}
+
+func resolve[T any](ctx context.Context, c Client, r ResourceURI) (interface{}, error) {
+	resp, err := get[T](ctx, c, r.Path(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ResolveURI is like Client.Resolve, but returns an error without making a
+// request if uri does not identify entities of type T.
+func ResolveURI[T any](ctx context.Context, c Client, uri string) (*Response[T], error) {
+	r, err := c.parseResourceURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if k := kindOf[T](); r.Entities() != k {
+		return nil, fmt.Errorf("marvel: %q identifies %s, not %s", uri, r.Entities(), k)
+	}
+	return get[T](ctx, c, r.Path(), nil)
+}
@@ -0,0 +1,80 @@
+package marvel
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestParseResourceURI(t *testing.T) {
+	for _, tc := range []struct {
+		uri  string
+		want ResourceURI
+	}{
+		{"http://gateway.marvel.com/v1/public/comics/21366", ResourceURI{Kind: KindComics, ID: 21366}},
+		{"https://Gateway.Marvel.com/v1/public/series/1987/characters", ResourceURI{Kind: KindSeries, ID: 1987, Collection: KindCharacters}},
+	} {
+		got, err := ParseResourceURI(tc.uri)
+		if err != nil {
+			t.Errorf("ParseResourceURI(%q): %v", tc.uri, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseResourceURI(%q) = %+v, want %+v", tc.uri, got, tc.want)
+		}
+		if got.String() != "http://gateway.marvel.com/v1/public"+got.Path() {
+			t.Errorf("String() = %q", got.String())
+		}
+	}
+
+	for _, uri := range []string{
+		"",
+		"http://example.com/v1/public/comics/1",
+		"ftp://gateway.marvel.com/v1/public/comics/1",
+		"//gateway.marvel.com/v1/public/comics/1",
+		"http://gateway.marvel.com/v1/public/comics",
+		"http://gateway.marvel.com/v1/public/comics/x",
+		"http://gateway.marvel.com/v1/public/comics/-1",
+		"http://gateway.marvel.com/v1/public/widgets/1",
+		"http://gateway.marvel.com/v1/public/comics/1/comics",
+		"http://gateway.marvel.com/v1/public/comics/1/stories/2",
+	} {
+		if got, err := ParseResourceURI(uri); err == nil {
+			t.Errorf("ParseResourceURI(%q) = %+v, want error", uri, got)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	var paths []string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"code":200,"data":{"results":[{"id":1}]}}`))
+	}))
+	ctx := context.Background()
+
+	resp, err := c.Resolve(ctx, "http://gateway.marvel.com/v1/public/series/1987/characters")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if chars, ok := resp.(*CharactersResponse); !ok || *chars.Data.Results[0].ID != 1 {
+		t.Errorf("Resolve returned %#v, want *CharactersResponse", resp)
+	}
+	if resp, err := c.Resolve(ctx, c.BaseURL+"/events/116"); err != nil {
+		t.Errorf("Resolve: %v", err)
+	} else if _, ok := resp.(*EventsResponse); !ok {
+		t.Errorf("Resolve returned %T, want *EventsResponse", resp)
+	}
+
+	if _, err := ResolveURI[Creator](ctx, c, "http://gateway.marvel.com/v1/public/comics/1"); err == nil {
+		t.Errorf("ResolveURI[Creator] of a comic succeeded")
+	}
+	if len(paths) != 2 {
+		t.Errorf("got requests %q, want 2", paths)
+	}
+
+	id, err := CreatorSummary{ResourceURI: strPtr("http://gateway.marvel.com/v1/public/creators/30")}.ID()
+	if err != nil || id != 30 {
+		t.Errorf("ID() = %d, %v, want 30", id, err)
+	}
+}
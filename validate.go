@@ -0,0 +1,253 @@
+package marvel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxIDs is the largest number of IDs the API accepts in a list filter.
+const maxIDs = 10
+
+// ValidationError reports request parameters that the API would reject.
+//
+// It matches ErrInvalidParameter using errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// FieldError describes a single invalid parameter.
+type FieldError struct {
+	// Field is the name of the parameter, as sent to the API.
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + ": " + f.Message
+	}
+	return "marvel: invalid parameters: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether target is ErrInvalidParameter.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidParameter
+}
+
+// validator accumulates FieldErrors.
+type validator struct {
+	errs []FieldError
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.errs}
+}
+
+func (v *validator) ids(field string, ids []int) {
+	if len(ids) > maxIDs {
+		v.add(field, "must not list more than %d IDs, got %d", maxIDs, len(ids))
+	}
+}
+
+// idString checks a comma-separated list of IDs.
+func (v *validator) idString(field, s string) {
+	if s == "" {
+		return
+	}
+	parts := strings.Split(s, ",")
+	for _, id := range parts {
+		if _, err := strconv.Atoi(strings.TrimSpace(id)); err != nil {
+			v.add(field, "invalid ID %q", id)
+			return
+		}
+	}
+	if len(parts) > maxIDs {
+		v.add(field, "must not list more than %d IDs, got %d", maxIDs, len(parts))
+	}
+}
+
+func (v *validator) notBlank(field, s string) {
+	if s != "" && strings.TrimSpace(s) == "" {
+		v.add(field, "must not be blank")
+	}
+}
+
+func (v *validator) exclusive(a string, aSet bool, b string, bSet bool) {
+	if aSet && bSet {
+		v.add(a, "must not be combined with %s", b)
+	}
+}
+
+func oneOf[T ~string](v *validator, field string, vals []T, allowed ...T) {
+	for _, val := range vals {
+		if val == "" {
+			continue
+		}
+		ok := false
+		for _, a := range allowed {
+			if val == a {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			v.add(field, "unsupported value %q", val)
+		}
+	}
+}
+
+func (p CommonParams) validate(v *validator) {
+	if p.Limit < 0 || p.Limit > maxLimit {
+		v.add("limit", "must be between 1 and %d, got %d", maxLimit, p.Limit)
+	}
+	if p.Offset < 0 {
+		v.add("offset", "must not be negative, got %d", p.Offset)
+	}
+}
+
+// Validate checks the parameters common to all requests.
+func (p CommonParams) Validate() error {
+	var v validator
+	p.validate(&v)
+	return v.err()
+}
+
+// Validate checks that the parameters would be accepted by the API.
+func (p CharactersParams) Validate() error {
+	var v validator
+	p.CommonParams.validate(&v)
+	oneOf(&v, "orderBy", p.OrderBy,
+		CharacterOrderName, CharacterOrderNameDesc, CharacterOrderModified, CharacterOrderModifiedDesc)
+	v.notBlank("name", p.Name)
+	v.notBlank("nameStartsWith", p.NameStartsWith)
+	v.exclusive("name", p.Name != "", "nameStartsWith", p.NameStartsWith != "")
+	v.ids("comics", p.Comics)
+	v.ids("events", p.Events)
+	v.ids("stories", p.Stories)
+	return v.err()
+}
+
+// Validate checks that the parameters would be accepted by the API.
+func (p ComicsParams) Validate() error {
+	var v validator
+	p.CommonParams.validate(&v)
+	oneOf(&v, "orderBy", p.OrderBy,
+		ComicOrderFocDate, ComicOrderFocDateDesc, ComicOrderOnSaleDate, ComicOrderOnSaleDateDesc,
+		ComicOrderTitle, ComicOrderTitleDesc, ComicOrderIssueNumber, ComicOrderIssueNumberDesc,
+		ComicOrderModified, ComicOrderModifiedDesc)
+	oneOf(&v, "format", []Format{p.Format},
+		FormatComic, FormatMagazine, FormatTradePaperback, FormatHardcover,
+		FormatDigest, FormatGraphicNovel, FormatDigitalComic, FormatInfiniteComic)
+	oneOf(&v, "formatType", []FormatType{p.FormatType}, FormatTypeComic, FormatTypeCollection)
+	oneOf(&v, "dateDescriptor", []DateDescriptor{p.DateDescriptor}, LastWeek, ThisWeek, NextWeek, ThisMonth)
+	v.exclusive("dateDescriptor", p.DateDescriptor != "", "dateRange", !p.DateRange.IsZero())
+	if !p.DateRange.IsZero() {
+		if err := p.DateRange.check(); err != nil {
+			v.add("dateRange", "%v", err)
+		}
+	}
+	v.notBlank("diamondCode", p.DiamondCode)
+	v.notBlank("digitalId", p.DigitalID)
+	v.notBlank("upc", p.UPC)
+	v.notBlank("isbn", p.ISBN)
+	v.notBlank("ean", p.EAN)
+	v.notBlank("issn", p.ISSN)
+	v.ids("creators", p.Creators)
+	v.ids("characters", p.Characters)
+	v.ids("events", p.Events)
+	v.ids("stories", p.Stories)
+	v.ids("sharedAppearances", p.SharedAppearances)
+	v.ids("collaborators", p.Collaborators)
+	return v.err()
+}
+
+// Validate checks that the parameters would be accepted by the API.
+func (p CreatorsParams) Validate() error {
+	var v validator
+	p.CommonParams.validate(&v)
+	oneOf(&v, "orderBy", p.OrderBy,
+		CreatorOrderLastName, CreatorOrderLastNameDesc, CreatorOrderFirstName, CreatorOrderFirstNameDesc,
+		CreatorOrderMiddleName, CreatorOrderMiddleNameDesc, CreatorOrderSuffix, CreatorOrderSuffixDesc,
+		CreatorOrderModified, CreatorOrderModifiedDesc)
+	for _, f := range []struct {
+		name, startsWith string
+		value, prefix    string
+	}{
+		{"firstName", "firstNameStartsWith", p.FirstName, p.FirstNameStartsWith},
+		{"middleName", "middleNameStartsWith", p.MiddleName, p.MiddleNameStartsWith},
+		{"lastName", "lastNameStartsWith", p.LastName, p.LastNameStartsWith},
+	} {
+		v.notBlank(f.name, f.value)
+		v.notBlank(f.startsWith, f.prefix)
+		v.exclusive(f.name, f.value != "", f.startsWith, f.prefix != "")
+	}
+	v.notBlank("suffix", p.Suffix)
+	v.notBlank("nameStartsWith", p.NameStartsWith)
+	v.ids("comics", p.Comics)
+	v.ids("events", p.Events)
+	v.ids("stories", p.Stories)
+	return v.err()
+}
+
+// Validate checks that the parameters would be accepted by the API.
+func (p EventsParams) Validate() error {
+	var v validator
+	p.CommonParams.validate(&v)
+	oneOf(&v, "orderBy", p.OrderBy,
+		EventOrderName, EventOrderNameDesc, EventOrderStartDate, EventOrderStartDateDesc,
+		EventOrderModified, EventOrderModifiedDesc)
+	v.notBlank("name", p.Name)
+	v.notBlank("nameStartsWith", p.NameStartsWith)
+	v.exclusive("name", p.Name != "", "nameStartsWith", p.NameStartsWith != "")
+	v.ids("creators", p.Creators)
+	v.ids("characters", p.Characters)
+	v.ids("comics", p.Comics)
+	v.ids("stories", p.Stories)
+	return v.err()
+}
+
+// Validate checks that the parameters would be accepted by the API.
+func (p SeriesParams) Validate() error {
+	var v validator
+	p.CommonParams.validate(&v)
+	oneOf(&v, "orderBy", p.OrderBy,
+		SeriesOrderTitle, SeriesOrderTitleDesc, SeriesOrderModified, SeriesOrderModifiedDesc,
+		SeriesOrderStartYear, SeriesOrderStartYearDesc)
+	oneOf(&v, "seriesType", []SeriesType{p.SeriesType},
+		SeriesTypeCollection, SeriesTypeOneShot, SeriesTypeLimited, SeriesTypeOngoing)
+	oneOf(&v, "contains", p.Contains,
+		FormatComic, FormatMagazine, FormatTradePaperback, FormatHardcover,
+		FormatDigest, FormatGraphicNovel, FormatDigitalComic, FormatInfiniteComic)
+	v.notBlank("title", p.Title)
+	v.notBlank("titleStartsWith", p.TitleStartsWith)
+	v.exclusive("title", p.Title != "", "titleStartsWith", p.TitleStartsWith != "")
+	if p.StartYear < 0 {
+		v.add("startYear", "must not be negative, got %d", p.StartYear)
+	}
+	v.idString("events", p.Events)
+	v.ids("comics", p.Comics)
+	v.ids("creators", p.Creators)
+	v.ids("characters", p.Characters)
+	return v.err()
+}
+
+// Validate checks that the parameters would be accepted by the API.
+func (p StoriesParams) Validate() error {
+	var v validator
+	p.CommonParams.validate(&v)
+	oneOf(&v, "orderBy", p.OrderBy, StoryOrderID, StoryOrderIDDesc, StoryOrderModified, StoryOrderModifiedDesc)
+	v.ids("comics", p.Comics)
+	v.ids("events", p.Events)
+	v.ids("creators", p.Creators)
+	v.ids("characters", p.Characters)
+	return v.err()
+}
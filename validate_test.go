@@ -0,0 +1,81 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	many := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	for _, tc := range []struct {
+		params interface{ Validate() error }
+		fields []string
+	}{
+		{CharactersParams{}, nil},
+		{CharactersParams{CommonParams: CommonParams{Limit: 100}, Comics: many[:10]}, nil},
+		{CharactersParams{CommonParams: CommonParams{Limit: 101, Offset: -1}}, []string{"limit", "offset"}},
+		{CharactersParams{Name: "Hulk", NameStartsWith: "Hu"}, []string{"name"}},
+		{CharactersParams{NameStartsWith: " "}, []string{"nameStartsWith"}},
+		{CharactersParams{OrderBy: []CharacterOrder{"title"}}, []string{"orderBy"}},
+		{ComicsParams{Characters: many, Creators: many}, []string{"creators", "characters"}},
+		{ComicsParams{Format: "floppy", DateDescriptor: ThisWeek, DateRange: DateRange{Start: time.Now()}}, []string{"format", "dateDescriptor", "dateRange"}},
+		{ComicsParams{ISSN: " ", UPC: " ", DiamondCode: " ", EAN: " "}, []string{"diamondCode", "upc", "ean", "issn"}},
+		{CreatorsParams{FirstName: "Stan", FirstNameStartsWith: "St"}, []string{"firstName"}},
+		{EventsParams{Stories: many}, []string{"stories"}},
+		{SeriesParams{Events: "1,2,3"}, nil},
+		{SeriesParams{Title: "X", TitleStartsWith: "X", Events: "1,2,3,4,5,6,7,8,9,10,11"}, []string{"title", "events"}},
+		{SeriesParams{Events: "1,Civil War"}, []string{"events"}},
+		{SeriesParams{Contains: []Format{FormatComic, "pamphlet"}}, []string{"contains"}},
+		{StoriesParams{OrderBy: []StoryOrder{StoryOrderIDDesc}, Comics: many}, []string{"comics"}},
+	} {
+		err := tc.params.Validate()
+		if tc.fields == nil {
+			if err != nil {
+				t.Errorf("%#v: Validate() = %v, want nil", tc.params, err)
+			}
+			continue
+		}
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("%#v: Validate() = %v, want *ValidationError", tc.params, err)
+			continue
+		}
+		var got []string
+		for _, f := range verr.Fields {
+			got = append(got, f.Field)
+		}
+		if len(got) != len(tc.fields) {
+			t.Errorf("%#v: invalid fields = %v, want %v", tc.params, got, tc.fields)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.fields[i] {
+				t.Errorf("%#v: invalid fields = %v, want %v", tc.params, got, tc.fields)
+				break
+			}
+		}
+		if !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("errors.Is(%v, ErrInvalidParameter) = false", err)
+		}
+	}
+}
+
+func TestValidateBeforeRequest(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	_, err := c.ComicsContext(context.Background(), ComicsParams{
+		CommonParams: CommonParams{Limit: 500},
+		Characters:   make([]int, 11),
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ComicsContext() = %v, want *ValidationError", err)
+	}
+	if len(verr.Fields) != 2 {
+		t.Errorf("Fields = %v, want limit and characters", verr.Fields)
+	}
+}